@@ -14,9 +14,11 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strconv"
@@ -26,7 +28,11 @@ import (
 	"scorex/internal/config"
 	"scorex/internal/model"
 	"scorex/internal/service/knowngood"
+	"scorex/internal/service/module"
 	"scorex/internal/service/projectinit"
+	"scorex/internal/service/skeleton"
+	"scorex/internal/service/templates"
+	"scorex/internal/service/textdiff"
 )
 
 type initOptions struct {
@@ -37,8 +43,24 @@ type initOptions struct {
 	BazelVersion string
 	ProjectType  string // Application|Module
 	AppType      string // daal|feo
+	Template     string
+	TemplateDir  string
 	IncludeDevcontainer bool
 	ModulePreset string
+	DryRun       bool
+	Diff         bool
+	OnConflict   string // fail|skip|overwrite|backup, or ask in interactive mode
+	PinMode      string // git|registry|archive
+	Local        map[string]string // module -> local checkout
+	Required     string            // add|fail
+	Confirmed    []string          // unknown module names kept in interactive mode
+	MaxAge       string            // e.g. 14d
+	OnStale      string            // warn|fail
+	RequireSuite string
+
+	KnownGoodSHA256   string
+	KnownGoodOverlays []string
+	ResolveConflict   skeleton.ConflictResolver
 }
 
 var initOpts = initOptions{}
@@ -53,6 +75,10 @@ var initCmd = &cobra.Command{
 			return err
 		}
 
+		if err := applyTemplateOptions(&initOpts); err != nil {
+			return err
+		}
+
 		if err := validateInitOptions(initOpts); err != nil {
 			return err
 		}
@@ -62,21 +88,30 @@ var initCmd = &cobra.Command{
 				if err := applyPresetNonInteractive(&initOpts); err != nil {
 					return err
 				}
-				return runInit(initOpts)
+				return runInit(cmd.Context(), initOpts)
 			}
-			return runInitInteractive(&initOpts)
+			// Ask about each conflicting file unless a policy was given explicitly.
+			if !cmd.Flags().Changed("on-conflict") {
+				initOpts.OnConflict = string(skeleton.ConflictAsk)
+			}
+			return runInitInteractive(cmd.Context(), &initOpts)
 		}
-		return runInit(initOpts)
+		return runInit(cmd.Context(), initOpts)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	addResolverFlags(initCmd)
+	addNoDepsFlag(initCmd)
+	addRegistryURLFlag(initCmd)
+	addCatalogURLFlag(initCmd)
+	addJobsFlag(initCmd)
 
 	initOpts.ProjectType = "Application"
 	initOpts.AppType = "daal"
 
-	initCmd.Flags().StringSliceVar(&initOpts.Modules, "module", nil, "S-CORE Module(s), e.g.: score_communication, score_baselibs")
+	initCmd.Flags().StringSliceVar(&initOpts.Modules, "module", nil, "S-CORE Module(s), e.g.: score_communication, score_baselibs; pin one to a ref with score_baselibs@<commit>, @v0.3.1 or @branch:feature/x")
 	initCmd.Flags().StringVar(&initOpts.Name, "name", config.DefaultProjectName, "name of the generated project")
 	initCmd.Flags().StringVar(&initOpts.TargetDir, "dir", config.DefaultTargetDir, "targetdirectory of the generated project")
 	initCmd.Flags().StringVar(
@@ -85,43 +120,143 @@ func init() {
 		config.DefaultKnownGoodURL,
 		"URL or path to known_good.json",
 	)
+	initCmd.Flags().StringArrayVar(&initOpts.KnownGoodOverlays, "known-good", nil, "known_good.json layered over --known-good-url; repeatable, later ones override the modules of earlier ones")
+	initCmd.Flags().StringVar(&initOpts.MaxAge, "max-known-good-age", "", "maximum age of known_good.json, e.g. 14d, 2w or 36h; recorded in scorex.lock")
+	initCmd.Flags().StringVar(&initOpts.OnStale, "on-stale-known-good", knowngood.StaleWarn, "what to do if known_good.json is older than --max-known-good-age: warn or fail")
+	initCmd.Flags().StringVar(&initOpts.RequireSuite, "require-suite", "", "reject known_good.json produced by another integration suite; recorded in scorex.lock")
+	initCmd.Flags().StringVar(&initOpts.KnownGoodSHA256, "known-good-sha256", "", "expected SHA-256 of known_good.json; pinned in scorex.json")
 	initCmd.Flags().StringVar(&initOpts.BazelVersion, "bazel-version", config.DefaultBazelVersion, "bazel version to be used in project")
 	initCmd.Flags().StringVar(&initOpts.ProjectType, "project-type", initOpts.ProjectType, "project type: Application or Module")
 	initCmd.Flags().StringVar(&initOpts.AppType, "app-type", initOpts.AppType, "application type (for Application projects): daal or feo")
+	initCmd.Flags().StringVar(&initOpts.Template, "template", "", "template ID to generate from (overrides --project-type and --app-type)")
+	initCmd.Flags().StringVar(&initOpts.TemplateDir, "template-dir", "", "additional directory with templates (each with a template.json), layered over ~/.config/scorex/templates and the built-in ones")
 	initCmd.Flags().BoolVar(&initOpts.IncludeDevcontainer, "devcontainer", false, "include a .devcontainer folder")
 	initCmd.Flags().StringVar(&initOpts.ModulePreset, "module-preset", "", "use a predefined module preset (e.g. feo-standard, daal-standard)")
+	initCmd.Flags().BoolVar(&initOpts.DryRun, "dry-run", false, "list the files that would be created or changed without writing anything")
+	initCmd.Flags().BoolVar(&initOpts.Diff, "diff", false, "print a unified diff against the files on disk without writing anything")
+	initCmd.Flags().StringVar(
+		&initOpts.Required,
+		"required-modules",
+		string(projectinit.RequiredAdd),
+		"what to do if the selection lacks modules the template requires: add them or fail",
+	)
+	initCmd.Flags().StringToStringVar(
+		&initOpts.Local,
+		"local",
+		nil,
+		"use a local checkout for a module via local_path_override, e.g. score_communication=../communication (relative to the project directory)",
+	)
+	initCmd.Flags().StringVar(
+		&initOpts.PinMode,
+		"pin-mode",
+		module.PinGit,
+		"how modules are pinned in MODULE.bazel: git (git_override), registry (plain bazel_dep if the registry has the version) or archive (archive_override with integrity)",
+	)
+	initCmd.Flags().StringVar(&initOpts.OnConflict, "on-conflict", string(skeleton.ConflictFail), "what to do with existing files that differ: fail, skip, overwrite or backup (keeps *.orig)")
 }
 
-func runInit(opts initOptions) error {
+func runInit(ctx context.Context, opts initOptions) error {
+	resolver, err := fallbackResolver()
+	if err != nil {
+		return err
+	}
+
+	policy, err := knownGoodPolicy(opts.MaxAge, opts.OnStale, opts.RequireSuite)
+	if err != nil {
+		return err
+	}
+
 	piOpts := projectinit.Options{
 		Modules:             opts.Modules,
 		TargetDir:           opts.TargetDir,
 		Name:                opts.Name,
 		KnownGoodURL:         opts.KnownGoodURL,
+		KnownGoodOverlays:   opts.KnownGoodOverlays,
 		BazelVersion:        opts.BazelVersion,
 		ProjectType:         opts.ProjectType,
 		AppType:             opts.AppType,
+		Template:            opts.Template,
+		TemplateDir:         opts.TemplateDir,
 		IncludeDevcontainer: opts.IncludeDevcontainer,
-	}
-
-	result, err := projectinit.Run(piOpts)
+		KnownGood:           knownGoodOptions(opts.KnownGoodSHA256),
+		Policy:              policy,
+		Resolver:            resolver,
+		NoDependencies:      globalOpts.NoDependencies,
+		PinMode:             opts.PinMode,
+		Local:               opts.Local,
+		ModulePreset:        opts.ModulePreset,
+		ConfirmedModules:    opts.Confirmed,
+		Required:            projectinit.RequiredPolicy(opts.Required),
+		RegistryURL:         globalOpts.RegistryURL,
+		Jobs:                globalOpts.Jobs,
+		DryRun:              opts.DryRun,
+		Diff:                opts.Diff,
+		OnConflict:          skeleton.ConflictPolicy(opts.OnConflict),
+		ResolveConflict:     opts.ResolveConflict,
+	}
+
+	result, err := projectinit.Run(ctx, piOpts)
 	if err != nil {
 		return err
 	}
+	printWarnings(result.Warnings)
+	printKnownGood(result.KnownGood, policy)
+	for _, m := range result.Required {
+		fmt.Println("Added required module", m, "of the template")
+	}
+	printDependencies(result.Dependencies)
+
+	if opts.DryRun || opts.Diff {
+		printChanges(result, opts.Diff)
+		return nil
+	}
 
 	fmt.Println("Generating skeleton in", result.TargetDir, "with modules:", result.SelectedModules)
+	for _, b := range result.BackedUp {
+		fmt.Printf("  kept previous %s as %s\n", filepath.ToSlash(b.Path), filepath.ToSlash(b.To))
+	}
+	for _, p := range result.Skipped {
+		fmt.Printf("  skipped existing %s\n", filepath.ToSlash(p))
+	}
 	return nil
 }
 
-func runInitInteractive(opts *initOptions) error {
+func printChanges(result *projectinit.Result, diff bool) {
+	if !diff {
+		fmt.Println("Dry run: no files written to", result.TargetDir)
+		for _, c := range result.Changes {
+			fmt.Printf("  %-9s %s\n", c.Kind, filepath.ToSlash(c.Path))
+		}
+		return
+	}
+
+	for _, c := range result.Changes {
+		if c.Kind == skeleton.ChangeUnchanged {
+			continue
+		}
+		oldName := "a/" + filepath.ToSlash(c.Path)
+		if c.Kind == skeleton.ChangeCreate {
+			oldName = "/dev/null"
+		}
+		fmt.Print(textdiff.Unified(oldName, "b/"+filepath.ToSlash(c.Path), string(c.Old), string(c.Content)))
+	}
+}
+
+func runInitInteractive(ctx context.Context, opts *initOptions) error {
 	reader := bufio.NewReader(os.Stdin)
 
+	if opts.OnConflict == string(skeleton.ConflictAsk) {
+		opts.ResolveConflict = func(c skeleton.FileChange) (skeleton.ConflictPolicy, error) {
+			return promptConflict(ctx, reader, c)
+		}
+	}
+
 	appChar := "a"
 	moduleChar := "m"
 
 	// project type
 	fmt.Printf("Project type (%s = application, %s = module): ", appChar, moduleChar)
-	v, err := readLine(reader)
+	v, err := readLine(ctx, reader)
 	if err != nil {
 		return err
 	}
@@ -142,7 +277,7 @@ func runInitInteractive(opts *initOptions) error {
 		daalChar := "d"
 
 		fmt.Printf("Application type (%s = FEO, %s = DAAL): ", feoChar, daalChar)
-		v, err := readLine(reader)
+		v, err := readLine(ctx, reader)
 		if err != nil {
 			return err
 		}
@@ -160,7 +295,7 @@ func runInitInteractive(opts *initOptions) error {
 
 	// project name
 	fmt.Printf("Project name [%s]: ", opts.Name)
-	if v, err := readLine(reader); err != nil {
+	if v, err := readLine(ctx, reader); err != nil {
 		return err
 	} else if v != "" {
 		opts.Name = v
@@ -168,14 +303,14 @@ func runInitInteractive(opts *initOptions) error {
 
 	// target directory
 	fmt.Printf("Target directory [%s]: ", opts.TargetDir)
-	if v, err := readLine(reader); err != nil {
+	if v, err := readLine(ctx, reader); err != nil {
 		return err
 	} else if v != "" {
 		opts.TargetDir = v
 	}
 
     // devcontainer
-    devcontainer, err := confirm(reader, "Use .devcontainer?")
+    devcontainer, err := confirm(ctx, reader, "Use .devcontainer?")
     if err != nil {
         return err
     } else {
@@ -183,24 +318,30 @@ func runInitInteractive(opts *initOptions) error {
     }
 
 	// load known-good
-	kg, err := knowngood.Load(opts.KnownGoodURL)
+	kg, err := knowngood.LoadMerged(ctx, opts.KnownGoodURL, opts.KnownGoodOverlays, knownGoodOptions(opts.KnownGoodSHA256))
 	if err != nil {
 		return fmt.Errorf("error loading known_good.json: %w", err)
 	}
 
+	// the catalog only adds descriptions, so carry on without it
+	catalog, err := moduleCatalog(ctx)
+	if err != nil {
+		printWarnings([]string{err.Error()})
+	}
+
 	// presets (optional)
-	if err := applyPresetInteractive(reader, opts, kg.Modules); err != nil {
+	if err := applyPresetInteractive(ctx, reader, opts, kg.Modules, catalog); err != nil {
 		return err
 	}
 	if len(opts.Modules) > 0 {
 		if err := validateInitOptions(*opts); err != nil {
 			return err
 		}
-		return runInit(*opts)
+		return runInit(ctx, *opts)
 	}
 
 	// choose modules
-	modules, err := promptModules(reader, kg.Modules)
+	modules, confirmed, err := promptModules(ctx, reader, kg.Modules, catalog)
 	if err != nil {
 		return err
 	}
@@ -208,11 +349,31 @@ func runInitInteractive(opts *initOptions) error {
 		return fmt.Errorf("no modules selected")
 	}
 	opts.Modules = modules
+	opts.Confirmed = append(opts.Confirmed, confirmed...)
 
 	if err := validateInitOptions(*opts); err != nil {
 		return err
 	}
-	return runInit(*opts)
+	return runInit(ctx, *opts)
+}
+
+// applyTemplateOptions takes project and application type from the manifest
+// of an explicitly selected template.
+func applyTemplateOptions(opts *initOptions) error {
+	if opts.Template == "" {
+		return nil
+	}
+	set, err := templates.Load(opts.TemplateDir)
+	if err != nil {
+		return err
+	}
+	t, ok := set.Lookup(opts.Template)
+	if !ok {
+		return fmt.Errorf("unknown --template %q (known: %s)", opts.Template, strings.Join(set.IDs(), ", "))
+	}
+	opts.ProjectType = t.ProjectType
+	opts.AppType = t.AppType
+	return nil
 }
 
 func applyPresetNonInteractive(opts *initOptions) error {
@@ -237,7 +398,7 @@ func applyPresetNonInteractive(opts *initOptions) error {
 	return nil
 }
 
-func applyPresetInteractive(reader *bufio.Reader, opts *initOptions, known map[string]model.ModuleInfo) error {
+func applyPresetInteractive(ctx context.Context, reader *bufio.Reader, opts *initOptions, known map[string]model.ModuleInfo, catalog *model.ModuleCatalog) error {
 	all, err := config.LoadModulePresets()
 	if err != nil {
 		return err
@@ -254,7 +415,7 @@ func applyPresetInteractive(reader *bufio.Reader, opts *initOptions, known map[s
 	}
 	fmt.Print("Select preset [0]: ")
 
-	v, err := readLine(reader)
+	v, err := readLine(ctx, reader)
 	if err != nil {
 		return err
 	}
@@ -277,8 +438,9 @@ func applyPresetInteractive(reader *bufio.Reader, opts *initOptions, known map[s
 	}
 
 	opts.Modules = append([]string(nil), preset.Modules...)
+	opts.ModulePreset = preset.ID
 
-	addMore, err := confirm(reader, "Add more modules on top of the preset?")
+	addMore, err := confirm(ctx, reader, "Add more modules on top of the preset?")
 	if err != nil {
 		return err
 	}
@@ -286,11 +448,12 @@ func applyPresetInteractive(reader *bufio.Reader, opts *initOptions, known map[s
 		return nil
 	}
 
-	extra, err := promptModules(reader, known)
+	extra, confirmed, err := promptModules(ctx, reader, known, catalog)
 	if err != nil {
 		return err
 	}
 	opts.Modules = mergeUnique(opts.Modules, extra)
+	opts.Confirmed = append(opts.Confirmed, confirmed...)
 	return nil
 }
 
@@ -329,7 +492,8 @@ func validateInitOptions(opts initOptions) error {
 		return fmt.Errorf("invalid --project-type %q (use Application or Module)", opts.ProjectType)
 	}
 
-	if opts.ProjectType == "Application" {
+	// Custom templates bring their own application types.
+	if opts.ProjectType == "Application" && opts.Template == "" {
 		validAppTypes := []string{"daal", "feo"}
 		if opts.AppType == "" {
 			return fmt.Errorf("--app-type must be set for Application projects (daal or feo)")
@@ -351,21 +515,71 @@ func validateInitOptions(opts initOptions) error {
 	if opts.BazelVersion == "" {
 		return fmt.Errorf("--bazel-version must be set")
 	}
+	if opts.KnownGoodSHA256 != "" {
+		if _, err := knowngood.NormalizeSHA256(opts.KnownGoodSHA256); err != nil {
+			return fmt.Errorf("invalid --known-good-sha256: %w", err)
+		}
+	}
+	if opts.OnConflict != string(skeleton.ConflictAsk) {
+		if _, err := skeleton.ParseConflictPolicy(opts.OnConflict); err != nil {
+			return fmt.Errorf("invalid --on-conflict: %w", err)
+		}
+	}
+	if _, err := projectinit.ParseRequiredPolicy(opts.Required); err != nil {
+		return fmt.Errorf("invalid --required-modules: %w", err)
+	}
+	if _, _, err := module.ParseModuleArgs(opts.Modules); err != nil {
+		return fmt.Errorf("invalid --module: %w", err)
+	}
+	if _, err := module.ParsePinMode(opts.PinMode); err != nil {
+		return fmt.Errorf("invalid --pin-mode: %w", err)
+	}
+	if _, err := knownGoodPolicy(opts.MaxAge, opts.OnStale, opts.RequireSuite); err != nil {
+		return err
+	}
+	if err := checkOverlays(opts.KnownGoodOverlays); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-func readLine(r *bufio.Reader) (string, error) {
-	line, err := r.ReadString('\n')
-	if err != nil && err != io.EOF {
-		return "", err
-	}
-	return strings.TrimSpace(line), nil
+// readLine reads the next answer from r. It gives up waiting once ctx is
+// cancelled, e.g. by Ctrl-C. A read cannot be interrupted, so the goroutine
+// doing it is then abandoned; it ends when stdin delivers a line or is closed,
+// which at the latest happens when scorex exits. r must not be used again
+// after a cancelled read.
+func readLine(ctx context.Context, r *bufio.Reader) (string, error) {
+	type answer struct {
+		line string
+		err  error
+	}
+	ch := make(chan answer, 1)
+	go func() {
+		line, err := r.ReadString('\n')
+		ch <- answer{line, err}
+	}()
+
+	var a answer
+	select {
+	case <-ctx.Done():
+		fmt.Println() // end the prompt line
+		return "", ctx.Err()
+	case a = <-ch:
+	}
+	if a.err != nil && a.err != io.EOF {
+		return "", a.err
+	}
+	return strings.TrimSpace(a.line), nil
 }
 
-func promptModules(r *bufio.Reader, known map[string]model.ModuleInfo) ([]string, error) {
+// promptModules lets the user pick modules from known_good. Descriptions and
+// languages are taken from catalog, which may be nil. Names missing from
+// known_good that the user keeps although they look like typos are returned
+// as confirmed.
+func promptModules(ctx context.Context, r *bufio.Reader, known map[string]model.ModuleInfo, catalog *model.ModuleCatalog) (selected, confirmed []string, err error) {
 	if len(known) == 0 {
-		return nil, fmt.Errorf("no modules in known_good.json")
+		return nil, nil, fmt.Errorf("no modules in known_good.json")
 	}
 
 	// sorted list of module names
@@ -377,19 +591,25 @@ func promptModules(r *bufio.Reader, known map[string]model.ModuleInfo) ([]string
 
 	fmt.Println("\nAvailable S-CORE modules:")
 	for i, n := range names {
-		fmt.Printf("  [%d] %s\n", i+1, n)
+		fmt.Printf("  [%d] %s%s\n", i+1, n, catalogSummary(catalog, n))
 	}
 	fmt.Print("Select modules (comma-separated indices or names, e.g. 1,3 or score_foo): ")
 
-	sel, err := readLine(r)
+	sel, err := readLine(ctx, r)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if sel == "" {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	parts := strings.Split(sel, ",")
+	presets, err := config.LoadModulePresets()
+	if err != nil {
+		return nil, nil, err
+	}
+	candidates := append(module.KnownNames(known), config.PresetModules(presets)...)
+
 	var result []string
 	seen := make(map[string]struct{})
 	for _, p := range parts {
@@ -401,7 +621,7 @@ func promptModules(r *bufio.Reader, known map[string]model.ModuleInfo) ([]string
 		// Either an index into the known-good list, or a module name.
 		if idx, err := strconv.Atoi(p); err == nil {
 			if idx < 1 || idx > len(names) {
-				return nil, fmt.Errorf("invalid module index: %q", p)
+				return nil, nil, fmt.Errorf("invalid module index: %q", p)
 			}
 			name := names[idx-1]
 			if _, ok := seen[name]; !ok {
@@ -413,13 +633,25 @@ func promptModules(r *bufio.Reader, known map[string]model.ModuleInfo) ([]string
 
 		// Treat as module name.
 		name := p
-		if _, ok := known[name]; !ok {
-			ok, err := confirm(r, fmt.Sprintf("Module %q is not in known_good.json. Add anyway?", name))
-			if err != nil {
-				return nil, err
-			}
-			if !ok {
-				continue
+		if _, ok := known[module.NormalizeName(name)]; !ok {
+			if suggestions := module.Suggest(name, candidates); len(suggestions) > 0 {
+				corrected, err := promptSuggestion(ctx, r, name, suggestions)
+				if err != nil {
+					return nil, nil, err
+				}
+				if corrected == "" {
+					confirmed = append(confirmed, name)
+				} else {
+					name = corrected
+				}
+			} else {
+				ok, err := confirm(ctx, r, fmt.Sprintf("Module %q is not in known_good.json. Add anyway?", name))
+				if err != nil {
+					return nil, nil, err
+				}
+				if !ok {
+					continue
+				}
 			}
 		}
 		if _, ok := seen[name]; !ok {
@@ -427,13 +659,63 @@ func promptModules(r *bufio.Reader, known map[string]model.ModuleInfo) ([]string
 			seen[name] = struct{}{}
 		}
 	}
-	return result, nil
+	return result, confirmed, nil
+}
+
+// promptSuggestion offers the closest known names for a module missing from
+// known_good. It returns the chosen name, or "" if the user keeps name.
+func promptSuggestion(ctx context.Context, r *bufio.Reader, name string, suggestions []string) (string, error) {
+	fmt.Printf("Module %q is not in known_good.json. Did you mean:\n", name)
+	fmt.Printf("  [0] keep %s\n", name)
+	for i, s := range suggestions {
+		fmt.Printf("  [%d] %s\n", i+1, s)
+	}
+	fmt.Print("Select [1]: ")
+
+	v, err := readLine(ctx, r)
+	if err != nil {
+		return "", err
+	}
+	if v == "" {
+		v = "1"
+	}
+	idx, err := strconv.Atoi(v)
+	if err != nil || idx < 0 || idx > len(suggestions) {
+		return "", fmt.Errorf("invalid selection: %q", v)
+	}
+	if idx == 0 {
+		return "", nil
+	}
+	return suggestions[idx-1], nil
+}
+
+func promptConflict(ctx context.Context, r *bufio.Reader, c skeleton.FileChange) (skeleton.ConflictPolicy, error) {
+	for {
+		fmt.Printf("%s already exists and differs. [s]kip, [o]verwrite, [b]ackup to %s, [a]bort (s): ",
+			filepath.ToSlash(c.Path), skeleton.BackupSuffix)
+		v, err := readLine(ctx, r)
+		if err != nil {
+			return "", err
+		}
+		switch strings.ToLower(v) {
+		case "", "s", "skip":
+			return skeleton.ConflictSkip, nil
+		case "o", "overwrite":
+			return skeleton.ConflictOverwrite, nil
+		case "b", "backup":
+			return skeleton.ConflictBackup, nil
+		case "a", "abort":
+			return skeleton.ConflictFail, nil
+		default:
+			// keep asking
+		}
+	}
 }
 
-func confirm(r *bufio.Reader, prompt string) (bool, error) {
+func confirm(ctx context.Context, r *bufio.Reader, prompt string) (bool, error) {
 	for {
 		fmt.Printf("%s (y/N): ", prompt)
-		v, err := readLine(r)
+		v, err := readLine(ctx, r)
 		if err != nil {
 			return false, err
 		}
@@ -0,0 +1,176 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"scorex/internal/service/knowngood"
+	"scorex/internal/service/module"
+)
+
+var knownGoodDiffOutput string
+
+// knownGoodCmd represents the known-good command group
+var knownGoodCmd = &cobra.Command{
+	Use:   "known-good",
+	Short: "Inspects known_good.json manifests",
+}
+
+// knownGoodDiffCmd represents the known-good diff command
+var knownGoodDiffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Shows what changed between two known_good.json manifests",
+	Long: `Lists the modules added to and removed from the new manifest, and the modules
+whose version, commit, repository or branch changed. Commit changes within a
+GitHub repository link to the compare view. Both manifests are URLs or paths.
+--output markdown is suitable for merge request descriptions.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch knownGoodDiffOutput {
+		case "text", "json", "markdown":
+		default:
+			return fmt.Errorf("invalid --output %q (use text, json or markdown)", knownGoodDiffOutput)
+		}
+
+		oldKG, err := knowngood.LoadWithOptions(cmd.Context(), args[0], knownGoodOptions(""))
+		if err != nil {
+			return fmt.Errorf("error loading %s: %w", args[0], err)
+		}
+		newKG, err := knowngood.LoadWithOptions(cmd.Context(), args[1], knownGoodOptions(""))
+		if err != nil {
+			return fmt.Errorf("error loading %s: %w", args[1], err)
+		}
+		for _, w := range oldKG.Warnings {
+			printWarnings([]string{args[0] + ": " + w})
+		}
+		for _, w := range newKG.Warnings {
+			printWarnings([]string{args[1] + ": " + w})
+		}
+		d := knowngood.Diff(oldKG, newKG)
+
+		switch knownGoodDiffOutput {
+		case "json":
+			data, err := json.MarshalIndent(d, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", data)
+			return nil
+		case "markdown":
+			writeDiffMarkdown(os.Stdout, args[0], args[1], d)
+			return nil
+		default:
+			writeDiffText(os.Stdout, d)
+			return nil
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(knownGoodCmd)
+	knownGoodCmd.AddCommand(knownGoodDiffCmd)
+
+	knownGoodDiffCmd.Flags().StringVarP(&knownGoodDiffOutput, "output", "o", "text", "output format: text, json or markdown")
+}
+
+func writeDiffText(w io.Writer, d *knowngood.ManifestDiff) {
+	if d.Empty() {
+		fmt.Fprintln(w, "No module changes")
+		return
+	}
+	if len(d.Added) > 0 {
+		fmt.Fprintln(w, "Added:")
+		for _, e := range d.Added {
+			fmt.Fprintf(w, "  %s %s (%s)\n", e.Name, e.Version, module.ShortHash(e.Hash))
+		}
+	}
+	if len(d.Removed) > 0 {
+		fmt.Fprintln(w, "Removed:")
+		for _, e := range d.Removed {
+			fmt.Fprintf(w, "  %s %s (%s)\n", e.Name, e.Version, module.ShortHash(e.Hash))
+		}
+	}
+	if len(d.Changed) > 0 {
+		fmt.Fprintln(w, "Changed:")
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "  %s\n", c.Name)
+			for _, line := range changeLines(c, false) {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+}
+
+func writeDiffMarkdown(w io.Writer, oldSource, newSource string, d *knowngood.ManifestDiff) {
+	fmt.Fprintf(w, "### known_good.json changes\n\n`%s` → `%s`\n\n", oldSource, newSource)
+	if d.Empty() {
+		fmt.Fprintln(w, "No module changes.")
+		return
+	}
+	if len(d.Added) > 0 {
+		fmt.Fprintln(w, "**Added**")
+		fmt.Fprintln(w)
+		for _, e := range d.Added {
+			fmt.Fprintf(w, "- `%s` %s (`%s`)\n", e.Name, e.Version, module.ShortHash(e.Hash))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(d.Removed) > 0 {
+		fmt.Fprintln(w, "**Removed**")
+		fmt.Fprintln(w)
+		for _, e := range d.Removed {
+			fmt.Fprintf(w, "- `%s` %s (`%s`)\n", e.Name, e.Version, module.ShortHash(e.Hash))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(d.Changed) > 0 {
+		fmt.Fprintln(w, "**Changed**")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "| Module | Changes |")
+		fmt.Fprintln(w, "| --- | --- |")
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "| `%s` | %s |\n", c.Name, strings.Join(changeLines(c, true), "<br>"))
+		}
+	}
+}
+
+// changeLines describes each changed field of c, one per line.
+func changeLines(c knowngood.ModuleChange, markdown bool) []string {
+	var out []string
+	if c.VersionChanged() {
+		out = append(out, fmt.Sprintf("version: %s → %s", orDash(c.Old.Version), orDash(c.New.Version)))
+	}
+	if c.HashChanged() {
+		commits := fmt.Sprintf("%s → %s", orDash(module.ShortHash(c.Old.Hash)), orDash(module.ShortHash(c.New.Hash)))
+		switch {
+		case c.CompareURL != "" && markdown:
+			commits = fmt.Sprintf("[%s](%s)", commits, c.CompareURL)
+		case c.CompareURL != "":
+			commits += " " + c.CompareURL
+		}
+		out = append(out, "commit: "+commits)
+	}
+	if c.RepoChanged() {
+		out = append(out, fmt.Sprintf("repo: %s → %s", orDash(c.Old.Repo), orDash(c.New.Repo)))
+	}
+	if c.BranchChanged() {
+		out = append(out, fmt.Sprintf("branch: %s → %s", orDash(c.Old.Branch), orDash(c.New.Branch)))
+	}
+	return out
+}
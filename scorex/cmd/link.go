@@ -0,0 +1,76 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"scorex/internal/service/projectupdate"
+)
+
+var linkOpts = projectupdate.LinkOptions{}
+
+// linkCmd represents the link command
+var linkCmd = &cobra.Command{
+	Use:   "link <name> <path>",
+	Short: "Uses a local checkout of an S-CORE module in an existing project",
+	Long: `Replaces the module's override in MODULE.bazel with a local_path_override on
+the given checkout and records it in scorex.json. The path is relative to the
+project directory and must contain a MODULE.bazel declaring the module.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := linkOpts
+		opts.Module = args[0]
+		opts.Path = args[1]
+
+		name, rm, err := projectupdate.Link(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Linked %s to %s in %s\n", name, rm.Pin.Path, opts.ProjectDir)
+		return nil
+	},
+}
+
+// unlinkCmd represents the unlink command
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink <name>",
+	Short: "Switches a linked S-CORE module back to its pinned version",
+	Long: `Replaces the module's local_path_override in MODULE.bazel with the pin from
+scorex.lock again, using the pin mode of the project.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := linkOpts
+		opts.Module = args[0]
+		opts.RegistryURL = globalOpts.RegistryURL
+		opts.Offline = globalOpts.Offline
+
+		name, rm, err := projectupdate.Unlink(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+		printWarnings(rm.Warnings)
+		fmt.Printf("Unlinked %s (commit %s) in %s\n", name, rm.Hash, opts.ProjectDir)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+	addRegistryURLFlag(unlinkCmd)
+
+	linkCmd.Flags().StringVar(&linkOpts.ProjectDir, "dir", ".", "directory of the project containing scorex.json")
+	unlinkCmd.Flags().StringVar(&linkOpts.ProjectDir, "dir", ".", "directory of the project containing scorex.json")
+}
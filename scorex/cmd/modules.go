@@ -0,0 +1,285 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"scorex/internal/config"
+	"scorex/internal/model"
+	"scorex/internal/service/knowngood"
+	"scorex/internal/service/module"
+)
+
+var (
+	modulesKnownGoodURL string
+	modulesOverlays     []string
+	modulesOutput       string
+)
+
+// moduleEntry is a module as printed by modules list and modules search.
+type moduleEntry struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Hash        string `json:"hash"`
+	Repo        string `json:"repo"`
+	Branch      string `json:"branch,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// modulesCmd represents the modules command group
+var modulesCmd = &cobra.Command{
+	Use:   "modules",
+	Short: "Inspects the S-CORE modules of known_good.json",
+	Long: `Inspects the S-CORE modules pinned in known_good.json, together with their
+descriptions from the module catalog (see --catalog-url).`,
+}
+
+// modulesListCmd represents the modules list command
+var modulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the modules of known_good.json",
+	Long: `Lists name, version, commit, repository and branch of every module in
+known_good.json. The table shows abbreviated commits; --output json and
+--output yaml show full ones.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateModulesOutput(); err != nil {
+			return err
+		}
+		kg, err := knowngood.LoadMerged(cmd.Context(), modulesKnownGoodURL, modulesOverlays, knownGoodOptions(""))
+		if err != nil {
+			return fmt.Errorf("error loading known_good.json: %w", err)
+		}
+		printWarnings(kg.WarningsFor(nil))
+
+		names := make([]string, 0, len(kg.Modules))
+		for name := range kg.Modules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		entries := make([]moduleEntry, 0, len(names))
+		for _, name := range names {
+			entries = append(entries, newModuleEntry(name, kg.Modules[name], ""))
+		}
+
+		if modulesOutput == "table" {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tVERSION\tCOMMIT\tREPOSITORY\tBRANCH")
+			for _, e := range entries {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					e.Name, orDash(e.Version), orDash(module.ShortHash(e.Hash)), orDash(e.Repo), orDash(e.Branch))
+			}
+			return w.Flush()
+		}
+		return writeModuleEntries(os.Stdout, entries)
+	},
+}
+
+// modulesSearchCmd represents the modules search command
+var modulesSearchCmd = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Searches module names and catalog descriptions",
+	Long: `Searches the modules of known_good.json and of the module catalog. Names and
+descriptions containing the term match best, followed by names containing its
+letters in order and words that differ from it by a typo or two.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateModulesOutput(); err != nil {
+			return err
+		}
+		catalog, err := moduleCatalog(cmd.Context())
+		if err != nil {
+			return err
+		}
+		kg, err := knowngood.LoadMerged(cmd.Context(), modulesKnownGoodURL, modulesOverlays, knownGoodOptions(""))
+		if err != nil {
+			return fmt.Errorf("error loading known_good.json: %w", err)
+		}
+		printWarnings(kg.WarningsFor(nil))
+
+		names := module.Search(args[0], kg.Modules, catalog)
+		entries := make([]moduleEntry, 0, len(names))
+		for _, name := range names {
+			entries = append(entries, newModuleEntry(name, kg.Modules[name], catalog.Modules[name].Description))
+		}
+
+		if modulesOutput == "table" {
+			if len(entries) == 0 {
+				fmt.Printf("No modules match %q\n", args[0])
+				return nil
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tVERSION\tDESCRIPTION")
+			for _, e := range entries {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", e.Name, orDash(e.Version), orDash(e.Description))
+			}
+			return w.Flush()
+		}
+		return writeModuleEntries(os.Stdout, entries)
+	},
+}
+
+// modulesInfoCmd represents the modules info command
+var modulesInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Shows the catalog entry and known_good pin of a module",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := module.NormalizeName(args[0])
+
+		catalog, err := moduleCatalog(cmd.Context())
+		if err != nil {
+			return err
+		}
+		entry, inCatalog := catalog.Modules[name]
+
+		// The catalog is still useful when known_good.json is not reachable.
+		var mi model.ModuleInfo
+		inKnownGood := false
+		kg, err := knowngood.LoadMerged(cmd.Context(), modulesKnownGoodURL, modulesOverlays, knownGoodOptions(""))
+		if err != nil {
+			printWarnings([]string{fmt.Sprintf("loading known_good.json: %v", err)})
+		} else {
+			mi, inKnownGood = kg.Modules[name]
+		}
+		if !inCatalog && !inKnownGood {
+			return fmt.Errorf("unknown module %q", name)
+		}
+
+		fmt.Printf("Name:         %s\n", name)
+		fmt.Printf("Description:  %s\n", orDash(entry.Description))
+		fmt.Printf("Languages:    %s\n", joinOrDash(entry.Languages))
+		fmt.Printf("Dependencies: %s\n", joinOrDash(entry.Dependencies))
+		if inKnownGood {
+			fmt.Printf("Version:      %s\n", orDash(mi.Version))
+			fmt.Printf("Commit:       %s\n", orDash(mi.Hash))
+			fmt.Printf("Repository:   %s\n", orDash(mi.Repo))
+			fmt.Printf("Branch:       %s\n", orDash(mi.Branch))
+		} else {
+			fmt.Println("Version:      - (not in known_good.json)")
+		}
+
+		fmt.Println("\nTargets:")
+		if len(entry.Targets) == 0 {
+			fmt.Println("  -")
+		}
+		for _, t := range entry.Targets {
+			fmt.Printf("  %s\n", t)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(modulesCmd)
+	modulesCmd.AddCommand(modulesListCmd)
+	modulesCmd.AddCommand(modulesSearchCmd)
+	modulesCmd.AddCommand(modulesInfoCmd)
+	addCatalogURLFlag(modulesSearchCmd)
+	addCatalogURLFlag(modulesInfoCmd)
+
+	modulesCmd.PersistentFlags().StringVar(&modulesKnownGoodURL, "known-good-url", config.DefaultKnownGoodURL, "URL or path to known_good.json")
+	modulesCmd.PersistentFlags().StringArrayVar(&modulesOverlays, "known-good", nil, "known_good.json layered over --known-good-url; repeatable, later ones override the modules of earlier ones")
+	for _, c := range []*cobra.Command{modulesListCmd, modulesSearchCmd} {
+		c.Flags().StringVarP(&modulesOutput, "output", "o", "table", "output format: table, json or yaml")
+	}
+}
+
+func validateModulesOutput() error {
+	switch modulesOutput {
+	case "table", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q (use table, json or yaml)", modulesOutput)
+	}
+}
+
+func newModuleEntry(name string, mi model.ModuleInfo, description string) moduleEntry {
+	return moduleEntry{
+		Name:        name,
+		Version:     mi.Version,
+		Hash:        mi.Hash,
+		Repo:        mi.Repo,
+		Branch:      mi.Branch,
+		Description: description,
+	}
+}
+
+// writeModuleEntries writes entries as JSON or YAML, depending on --output.
+func writeModuleEntries(w io.Writer, entries []moduleEntry) error {
+	if modulesOutput == "json" {
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	// The entries are flat, so YAML is written by hand. Double-quoted
+	// scalars with Go escapes are valid YAML.
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return err
+	}
+	for _, e := range entries {
+		fmt.Fprintf(w, "- name: %s\n", strconv.Quote(e.Name))
+		fmt.Fprintf(w, "  version: %s\n", strconv.Quote(e.Version))
+		fmt.Fprintf(w, "  hash: %s\n", strconv.Quote(e.Hash))
+		fmt.Fprintf(w, "  repo: %s\n", strconv.Quote(e.Repo))
+		if e.Branch != "" {
+			fmt.Fprintf(w, "  branch: %s\n", strconv.Quote(e.Branch))
+		}
+		if e.Description != "" {
+			fmt.Fprintf(w, "  description: %s\n", strconv.Quote(e.Description))
+		}
+	}
+	return nil
+}
+
+// catalogSummary returns " - description (languages)" for the module, or an
+// empty string if the catalog has no entry for it.
+func catalogSummary(catalog *model.ModuleCatalog, name string) string {
+	if catalog == nil {
+		return ""
+	}
+	entry, ok := catalog.Modules[name]
+	if !ok {
+		return ""
+	}
+	s := ""
+	if entry.Description != "" {
+		s = " - " + entry.Description
+	}
+	if len(entry.Languages) > 0 {
+		s += " (" + strings.Join(entry.Languages, ", ") + ")"
+	}
+	return s
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"scorex/internal/service/projectupdate"
+)
+
+var addModuleOpts = projectupdate.ModuleOptions{}
+var addModuleSHA256 string
+var removeModuleOpts = projectupdate.ModuleOptions{}
+
+// addModuleCmd represents the add-module command
+var addModuleCmd = &cobra.Command{
+	Use:   "add-module <name>[@<ref>]",
+	Short: "Adds an S-CORE module to an existing project",
+	Long: `Resolves the module against known_good.json (falling back to the --resolver),
+adds its bazel_dep/git_override blocks to MODULE.bazel and records it in scorex.json.
+S-CORE modules it depends on are added from known_good.json unless the project has them.
+A ref (<commit>, a tag such as v0.3.1, or branch:<name>) pins the module instead of its
+known_good.json entry and is kept in scorex.json.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := addModuleOpts
+		opts.Module = args[0]
+		opts.KnownGood = knownGoodOptions(addModuleSHA256)
+		if err := checkOverlays(opts.Overlays); err != nil {
+			return err
+		}
+		resolver, err := fallbackResolver()
+		if err != nil {
+			return err
+		}
+		opts.Resolver = resolver
+
+		opts.NoDependencies = globalOpts.NoDependencies
+		opts.RegistryURL = globalOpts.RegistryURL
+
+		result, err := projectupdate.AddModule(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+		printWarnings(result.Warnings)
+		fmt.Printf("Added %s (version %s, commit %s) to %s\n", result.Name, result.Module.Version, result.Module.Hash, opts.ProjectDir)
+		printDependencies(result.Dependencies)
+		return nil
+	},
+}
+
+// removeModuleCmd represents the remove-module command
+var removeModuleCmd = &cobra.Command{
+	Use:   "remove-module <name>",
+	Short: "Removes an S-CORE module from an existing project",
+	Long: `Removes the module's bazel_dep/git_override blocks from MODULE.bazel and drops it from scorex.json.
+Dependencies that were only added for this module are removed as well.
+Modules the project's template requires are only removed with --force.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := removeModuleOpts
+		opts.Module = args[0]
+
+		removed, err := projectupdate.RemoveModule(opts)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s from %s\n", strings.Join(removed, ", "), opts.ProjectDir)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(addModuleCmd)
+	rootCmd.AddCommand(removeModuleCmd)
+	addResolverFlags(addModuleCmd)
+	addNoDepsFlag(addModuleCmd)
+	addRegistryURLFlag(addModuleCmd)
+
+	addModuleCmd.Flags().StringVar(&addModuleOpts.ProjectDir, "dir", ".", "directory of the project containing scorex.json")
+	addModuleCmd.Flags().StringVar(
+		&addModuleOpts.KnownGoodURL,
+		"known-good-url",
+		"",
+		"URL or path to known_good.json (default: the one recorded in scorex.json)",
+	)
+	addModuleCmd.Flags().StringArrayVar(&addModuleOpts.Overlays, "known-good", nil, "known_good.json layered over --known-good-url; repeatable, later ones override the modules of earlier ones (default: the ones recorded in scorex.json)")
+	addModuleCmd.Flags().StringVar(&addModuleSHA256, "known-good-sha256", "", "expected SHA-256 of known_good.json (default: the pin in scorex.json)")
+
+	removeModuleCmd.Flags().StringVar(&removeModuleOpts.ProjectDir, "dir", ".", "directory of the project containing scorex.json")
+	removeModuleCmd.Flags().StringVar(&removeModuleOpts.TemplateDir, "template-dir", "", "additional directory with templates, searched for the project's template")
+	removeModuleCmd.Flags().BoolVar(&removeModuleOpts.Force, "force", false, "remove the module even if the project's template requires it")
+}
@@ -13,9 +13,20 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
+	"scorex/internal/config"
+	"scorex/internal/model"
+	"scorex/internal/service/httpfetch"
+	"scorex/internal/service/knowngood"
+	"scorex/internal/service/module"
 )
 
 // Version information, set via ldflags during build
@@ -25,6 +36,151 @@ var (
 	BuildDate = "unknown"
 )
 
+// globalOptions holds the flags shared by all commands.
+type globalOptions struct {
+	Offline         bool
+	CacheDir        string
+	RequireChecksum bool
+	Resolver        module.ResolverConfig
+	NoDependencies  bool
+	RegistryURL     string
+	CatalogURL      string
+	HTTP            httpfetch.Config
+	Jobs            int
+}
+
+var globalOpts = globalOptions{}
+
+// knownGoodOptions returns how to load known_good.json, expecting the given
+// SHA-256 if it is not empty.
+func knownGoodOptions(sha256 string) knowngood.LoadOptions {
+	return knowngood.LoadOptions{
+		Offline:         globalOpts.Offline,
+		CacheDir:        globalOpts.CacheDir,
+		ExpectedSHA256:  sha256,
+		RequireChecksum: globalOpts.RequireChecksum,
+	}
+}
+
+// fallbackResolver returns the resolver selected with --resolver for modules
+// missing from known_good.
+func fallbackResolver() (module.Resolver, error) {
+	return module.NewResolver(globalOpts.Resolver)
+}
+
+// moduleCatalog loads the embedded module catalog, layered with the one
+// selected with --catalog-url.
+func moduleCatalog(ctx context.Context) (*model.ModuleCatalog, error) {
+	return config.LoadModuleCatalog(ctx, globalOpts.CatalogURL, knownGoodOptions(""))
+}
+
+// knownGoodPolicy parses the --max-known-good-age, --on-stale-known-good and
+// --require-suite flags.
+func knownGoodPolicy(maxAge, onStale, suite string) (knowngood.Policy, error) {
+	age, err := knowngood.ParseMaxAge(maxAge)
+	if err != nil {
+		return knowngood.Policy{}, fmt.Errorf("invalid --max-known-good-age: %w", err)
+	}
+	if onStale != "" {
+		if _, err := knowngood.ParseStalePolicy(onStale); err != nil {
+			return knowngood.Policy{}, fmt.Errorf("invalid --on-stale-known-good: %w", err)
+		}
+	}
+	return knowngood.Policy{MaxAge: age, OnStale: onStale, RequireSuite: strings.TrimSpace(suite)}, nil
+}
+
+// checkOverlays rejects empty --known-good values, which would be loaded
+// as a file named "".
+func checkOverlays(overlays []string) error {
+	for _, o := range overlays {
+		if strings.TrimSpace(o) == "" {
+			return fmt.Errorf("invalid --known-good: empty path or URL")
+		}
+	}
+	return nil
+}
+
+// configureHTTP makes the client described by the --http-* flags, --ca-cert
+// and --netrc the one all downloads go through. It is set up on the first
+// download, so a broken setting does not affect commands that make none.
+func configureHTTP() {
+	cfg := globalOpts.HTTP
+	if cfg.Retries == 0 {
+		cfg.Retries = -1 // --http-retries=0 disables retries
+	}
+	httpfetch.Configure(cfg)
+}
+
+// addResolverFlags registers the --resolver flags on a command that resolves
+// modules missing from known_good.
+func addResolverFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&globalOpts.Resolver.Kind,
+		"resolver",
+		module.ResolverGitHub,
+		"how to resolve modules missing from known_good.json ("+strings.Join(module.ResolverKinds(), ", ")+")",
+	)
+	cmd.Flags().StringVar(&globalOpts.Resolver.Owner, "resolver-owner", module.DefaultOwner, "GitHub organisation the github resolver looks up repositories in")
+	cmd.Flags().StringVar(&globalOpts.Resolver.Host, "resolver-api-url", module.DefaultGitHubAPIURL, "GitHub API URL of the github resolver, e.g. https://github.example.com/api/v3")
+	cmd.Flags().StringVar(&globalOpts.Resolver.Branch, "resolver-branch", module.DefaultBranch, "branch the github and git resolvers take the latest commit from")
+	cmd.Flags().StringVar(
+		&globalOpts.Resolver.URL,
+		"resolver-url",
+		"",
+		"remote URL template with {repo} for the git resolver, or the base URL of the registry resolver",
+	)
+}
+
+// addNoDepsFlag registers --no-deps on a command that adds dependencies of
+// the selected modules.
+func addNoDepsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&globalOpts.NoDependencies, "no-deps", false, "do not add the S-CORE modules the selected modules depend on")
+}
+
+// addRegistryURLFlag registers --registry-url on a command that pins modules.
+func addRegistryURLFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&globalOpts.RegistryURL, "registry-url", module.DefaultRegistryURL, "Bazel registry checked by --pin-mode=registry")
+}
+
+// addCatalogURLFlag registers --catalog-url on a command that shows the
+// module catalog.
+func addCatalogURLFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&globalOpts.CatalogURL, "catalog-url", "", "URL or path of a module catalog layered over the embedded one")
+}
+
+// addJobsFlag registers --jobs on a command that resolves several modules.
+func addJobsFlag(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&globalOpts.Jobs, "jobs", module.DefaultJobs, "number of modules resolved concurrently")
+}
+
+// printKnownGood describes the manifest a project was resolved against and
+// the policy it was checked against.
+func printKnownGood(kg *model.KnownGood, policy knowngood.Policy) {
+	suite := kg.Suite
+	if suite == "" {
+		suite = "-"
+	}
+	from := "unknown date"
+	if ts, err := knowngood.ManifestTime(kg); err == nil {
+		from = fmt.Sprintf("%s, %s old", kg.Timestamp, knowngood.FormatAge(time.Since(ts)))
+	}
+	fmt.Printf("known_good.json: suite %s, from %s; policy: %s\n", suite, from, policy)
+}
+
+// printWarnings reports non-fatal problems on stderr.
+func printWarnings(warnings []string) {
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, "Warning:", w)
+	}
+}
+
+// printDependencies lists the modules that were added as dependencies.
+func printDependencies(deps []string) {
+	for _, d := range deps {
+		fmt.Println("Added dependency", d)
+	}
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "scorex",
@@ -41,13 +197,34 @@ var rootCmd = &cobra.Command{
 
 Welcome to scorex - a cli for S-CORE development!
 `,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		configureHTTP()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// Ctrl-C or SIGTERM cancel the context of the running command, which then
+// stops cleanly; a second Ctrl-C terminates scorex immediately.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	// Usage does not help with an interrupted command.
+	cobra.OnFinalize(func() {
+		if ctx.Err() != nil {
+			rootCmd.SilenceUsage = true
+		}
+	})
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
+		if ctx.Err() != nil {
+			os.Exit(130)
+		}
 		os.Exit(1)
 	}
 }
@@ -58,6 +235,14 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.scorex.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.Offline, "offline", false, "never access the network; resolve only from cached known_good.json files")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.CacheDir, "cache-dir", "", "directory for cached known_good.json files and module lookups (default: the user cache directory)")
+	rootCmd.PersistentFlags().BoolVar(&globalOpts.RequireChecksum, "require-known-good-checksum", false, "fail if no expected SHA-256 for known_good.json is available")
+	rootCmd.PersistentFlags().DurationVar(&globalOpts.HTTP.Timeout, "http-timeout", httpfetch.DefaultTimeout, "timeout of each HTTP request")
+	rootCmd.PersistentFlags().IntVar(&globalOpts.HTTP.Retries, "http-retries", httpfetch.DefaultRetries, "retries of HTTP requests that failed with a network error, 429 or 5xx")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.HTTP.Proxy, "http-proxy", "", "proxy URL for all HTTP requests (default: $HTTPS_PROXY, $HTTP_PROXY and $NO_PROXY)")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.HTTP.CAFile, "ca-cert", "", "PEM file with CA certificates trusted in addition to the system ones")
+	rootCmd.PersistentFlags().StringVar(&globalOpts.HTTP.NetrcFile, "netrc", "", "file with credentials per host (default: $NETRC or ~/.netrc)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
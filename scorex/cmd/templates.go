@@ -0,0 +1,124 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"scorex/internal/service/templates"
+)
+
+var templatesDir string
+
+// templatesCmd represents the templates command group
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Lists and inspects project templates",
+	Long: `Lists and inspects the templates init can generate from: the built-in ones,
+those in ~/.config/scorex/templates and those in --template-dir.`,
+}
+
+// templatesListCmd represents the templates list command
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all available templates",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		set, err := templates.Load(templatesDir)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTYPE\tDEFAULT MODULES\tSOURCE\tDESCRIPTION")
+		for _, t := range set.All() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				t.ID, templateType(t), joinOrDash(t.DefaultModules), t.Source, t.Description)
+		}
+		return w.Flush()
+	},
+}
+
+// templatesShowCmd represents the templates show command
+var templatesShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Shows the files and variables of a template",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		set, err := templates.Load(templatesDir)
+		if err != nil {
+			return err
+		}
+		t, ok := set.Lookup(args[0])
+		if !ok {
+			return fmt.Errorf("unknown template %q (known: %s)", args[0], strings.Join(set.IDs(), ", "))
+		}
+
+		files, err := t.Files()
+		if err != nil {
+			return err
+		}
+		vars, err := t.Variables()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("ID:               %s\n", t.ID)
+		fmt.Printf("Description:      %s\n", t.Description)
+		fmt.Printf("Type:             %s\n", templateType(t))
+		fmt.Printf("Source:           %s\n", t.Source)
+		fmt.Printf("Required modules: %s\n", joinOrDash(t.RequiredModules))
+		fmt.Printf("Default modules:  %s\n", joinOrDash(t.DefaultModules))
+
+		fmt.Println("\nFiles:")
+		for _, f := range files {
+			note := ""
+			if f.Optional {
+				note = " (with --devcontainer)"
+			}
+			fmt.Printf("  %s%s\n", f.Output, note)
+		}
+
+		fmt.Println("\nVariables:")
+		for _, v := range vars {
+			fmt.Printf("  %s\n", v)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesShowCmd)
+
+	templatesCmd.PersistentFlags().StringVar(&templatesDir, "template-dir", "", "additional directory with templates (each with a template.json)")
+}
+
+func templateType(t *templates.Template) string {
+	if t.AppType == "" {
+		return t.ProjectType
+	}
+	return t.ProjectType + "/" + t.AppType
+}
+
+func joinOrDash(s []string) string {
+	if len(s) == 0 {
+		return "-"
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,121 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"scorex/internal/service/module"
+	"scorex/internal/service/projectupdate"
+)
+
+type updateOptions struct {
+	ProjectDir      string
+	KnownGoodURL    string
+	KnownGoodSHA256 string
+	Overlays        []string
+	NoOverlays      bool
+	MaxAge          string
+	OnStale         string
+	RequireSuite    string
+	PinMode         string
+	Modules         []string
+}
+
+var updateOpts = updateOptions{}
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Re-resolves the modules of an existing S-CORE project",
+	Long: `Reads scorex.json of an existing project, resolves its modules against the
+current known_good.json and rewrites their bazel_dep/git_override blocks in
+MODULE.bazel. All other content of MODULE.bazel is kept as is.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if updateOpts.PinMode != "" {
+			if _, err := module.ParsePinMode(updateOpts.PinMode); err != nil {
+				return fmt.Errorf("invalid --pin-mode: %w", err)
+			}
+		}
+		policy, err := knownGoodPolicy(updateOpts.MaxAge, updateOpts.OnStale, updateOpts.RequireSuite)
+		if err != nil {
+			return err
+		}
+		if err := checkOverlays(updateOpts.Overlays); err != nil {
+			return fmt.Errorf("%w (use --no-known-good-overlays to drop the overlays in scorex.json)", err)
+		}
+		resolver, err := fallbackResolver()
+		if err != nil {
+			return err
+		}
+
+		result, err := projectupdate.Run(cmd.Context(), projectupdate.Options{
+			ProjectDir:     updateOpts.ProjectDir,
+			KnownGoodURL:   updateOpts.KnownGoodURL,
+			Overlays:       updateOpts.Overlays,
+			NoOverlays:     updateOpts.NoOverlays,
+			Policy:         policy,
+			KnownGood:      knownGoodOptions(updateOpts.KnownGoodSHA256),
+			Resolver:       resolver,
+			NoDependencies: globalOpts.NoDependencies,
+			PinMode:        updateOpts.PinMode,
+			RegistryURL:    globalOpts.RegistryURL,
+			Jobs:           globalOpts.Jobs,
+			Refs:           updateOpts.Modules,
+		})
+		if err != nil {
+			return err
+		}
+		printWarnings(result.Warnings)
+		printKnownGood(result.KnownGood, result.Policy)
+		printDependencies(result.Dependencies)
+
+		if !result.Changed {
+			fmt.Println("MODULE.bazel in", result.ProjectDir, "is up to date")
+			return nil
+		}
+		fmt.Println("Updated MODULE.bazel in", result.ProjectDir, "with modules:", result.SelectedModules)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+	addResolverFlags(updateCmd)
+	addNoDepsFlag(updateCmd)
+	addRegistryURLFlag(updateCmd)
+	addJobsFlag(updateCmd)
+
+	updateCmd.Flags().StringVar(&updateOpts.ProjectDir, "dir", ".", "directory of the project containing scorex.json")
+	updateCmd.Flags().StringVar(
+		&updateOpts.KnownGoodURL,
+		"known-good-url",
+		"",
+		"URL or path to known_good.json (default: the one recorded in scorex.json)",
+	)
+	updateCmd.Flags().StringArrayVar(&updateOpts.Overlays, "known-good", nil, "known_good.json layered over --known-good-url; repeatable, later ones override the modules of earlier ones; replaces the overlays in scorex.json")
+	updateCmd.Flags().BoolVar(&updateOpts.NoOverlays, "no-known-good-overlays", false, "drop the known_good overlays recorded in scorex.json")
+	updateCmd.MarkFlagsMutuallyExclusive("known-good", "no-known-good-overlays")
+	updateCmd.Flags().StringVar(&updateOpts.MaxAge, "max-known-good-age", "", "maximum age of known_good.json, e.g. 14d; replaces the one in scorex.lock")
+	updateCmd.Flags().StringVar(&updateOpts.OnStale, "on-stale-known-good", "", "warn or fail if known_good.json is too old; replaces the action in scorex.lock")
+	updateCmd.Flags().StringVar(&updateOpts.RequireSuite, "require-suite", "", "integration suite known_good.json must come from; replaces the one in scorex.lock")
+	updateCmd.Flags().StringVar(&updateOpts.KnownGoodSHA256, "known-good-sha256", "", "expected SHA-256 of known_good.json; replaces the pin in scorex.json")
+	updateCmd.Flags().StringSliceVar(
+		&updateOpts.Modules,
+		"module",
+		nil,
+		"pin a module to a ref (score_baselibs@<commit>, @v0.3.1 or @branch:feature/x), or back to known_good (score_baselibs)",
+	)
+	updateCmd.Flags().StringVar(&updateOpts.PinMode, "pin-mode", "", "git, registry or archive; replaces the pin mode in scorex.json")
+}
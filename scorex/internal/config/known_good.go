@@ -1,6 +1,8 @@
 package config
 
 import (
+    "context"
+
     "scorex/internal/model"
     "scorex/internal/service/knowngood"
 )
@@ -10,5 +12,5 @@ type KnownGood = model.KnownGood
 
 // Deprecated: use scorex/internal/service/knowngood.Load.
 func LoadKnownGood(urlOrPath string) (*KnownGood, error) {
-    return knowngood.Load(urlOrPath)
+    return knowngood.Load(context.Background(), urlOrPath)
 }
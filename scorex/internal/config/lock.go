@@ -0,0 +1,81 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"scorex/internal/model"
+	"scorex/internal/service/knowngood"
+)
+
+const DefaultLockFileName = "scorex.lock"
+
+// NewLock builds the lock for modules resolved against kg, checked against
+// policy. If kg has overlays, the effective manifest is recorded as well.
+func NewLock(kg *knowngood.Merged, policy knowngood.Policy, modules map[string]model.ResolvedModule) *model.Lock {
+	base := kg.Layers[0]
+	locked := lockedKnownGood(base)
+	locked.Policy = policy.Locked()
+	for _, l := range kg.Layers[1:] {
+		locked.Overlays = append(locked.Overlays, lockedKnownGood(l))
+	}
+	if len(locked.Overlays) > 0 {
+		locked.Modules = kg.Modules
+	}
+	return &model.Lock{KnownGood: locked, Modules: modules}
+}
+
+func lockedKnownGood(l knowngood.Layer) model.LockedKnownGood {
+	return model.LockedKnownGood{
+		URL:            l.Source,
+		SHA256:         l.KnownGood.ContentSHA256,
+		ManifestSHA256: l.KnownGood.ManifestSHA256,
+		Timestamp:      l.KnownGood.Timestamp,
+		Suite:          l.KnownGood.Suite,
+	}
+}
+
+// MarshalLock returns the scorex.lock content for lock.
+func MarshalLock(lock *model.Lock) ([]byte, error) {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func WriteLock(dir string, lock *model.Lock) error {
+	data, err := MarshalLock(lock)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, DefaultLockFileName), data, 0o644)
+}
+
+func ReadLock(dir string) (*model.Lock, error) {
+	data, err := os.ReadFile(filepath.Join(dir, DefaultLockFileName))
+	if err != nil {
+		return nil, err
+	}
+	var lock model.Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	if lock.Modules == nil {
+		lock.Modules = make(map[string]model.ResolvedModule)
+	}
+	return &lock, nil
+}
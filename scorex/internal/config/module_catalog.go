@@ -0,0 +1,75 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package config
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"scorex/internal/model"
+	"scorex/internal/service/knowngood"
+)
+
+//go:embed module_catalog.json
+var moduleCatalogJSON []byte
+
+// LoadModuleCatalog returns the embedded module catalog. If urlOrPath is set,
+// the catalog found there is layered on top: its entries replace embedded
+// entries of the same module. opts controls fetching and caching of URLs.
+func LoadModuleCatalog(ctx context.Context, urlOrPath string, opts knowngood.LoadOptions) (*model.ModuleCatalog, error) {
+	catalog, err := parseModuleCatalog(moduleCatalogJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing embedded module catalog: %w", err)
+	}
+	if urlOrPath == "" {
+		return catalog, nil
+	}
+
+	data, err := knowngood.ReadSource(ctx, urlOrPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("loading module catalog: %w", err)
+	}
+	extra, err := parseModuleCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing module catalog %s: %w", urlOrPath, err)
+	}
+	for name, entry := range extra.Modules {
+		catalog.Modules[name] = entry
+	}
+	return catalog, nil
+}
+
+func parseModuleCatalog(data []byte) (*model.ModuleCatalog, error) {
+	var raw model.ModuleCatalog
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	catalog := &model.ModuleCatalog{Modules: make(map[string]model.CatalogEntry, len(raw.Modules))}
+	for name, entry := range raw.Modules {
+		name = normalizeModuleName(name)
+		if name == "" {
+			return nil, fmt.Errorf("module catalog entry without name")
+		}
+		entry.Description = strings.TrimSpace(entry.Description)
+		for i := range entry.Dependencies {
+			entry.Dependencies[i] = normalizeModuleName(entry.Dependencies[i])
+		}
+		entry.Dependencies = dedupeStrings(entry.Dependencies)
+		catalog.Modules[name] = entry
+	}
+	return catalog, nil
+}
@@ -83,6 +83,16 @@ func FindModulePreset(all []ModulePreset, id string) (ModulePreset, bool) {
 	return ModulePreset{}, false
 }
 
+// PresetModules returns the modules of all presets, for suggestions when a
+// module name is misspelled.
+func PresetModules(all []ModulePreset) []string {
+	var out []string
+	for _, p := range all {
+		out = append(out, p.Modules...)
+	}
+	return dedupeStrings(out)
+}
+
 func normalizeModuleName(name string) string {
 	name = strings.TrimSpace(name)
 	if name == "" {
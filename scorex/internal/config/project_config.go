@@ -4,14 +4,31 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+
+	"scorex/internal/service/knowngood"
 )
 
 type ProjectConfig struct {
-	ProjectName  string   `json:"project_name"`
-	Template     string   `json:"template"`
-	BazelVersion string   `json:"bazel_version"`
-	KnownGoodURL string   `json:"known_good_url"`
-	Modules      []string `json:"modules"`
+	ProjectName     string   `json:"project_name"`
+	Template        string   `json:"template"`
+	BazelVersion    string   `json:"bazel_version"`
+	KnownGoodURL    string   `json:"known_good_url"`              // local paths are relative to the project directory
+	KnownGoodSHA256 string   `json:"known_good_sha256,omitempty"` // pinned SHA-256 of known_good.json
+	Modules         []string `json:"modules"`
+	PinMode         string   `json:"pin_mode,omitempty"` // git (default), registry or archive
+
+	// KnownGoodOverlays are layered over KnownGoodURL in order; later ones
+	// override the modules of earlier ones. Local paths are relative to the
+	// project directory, see ProjectPath.
+	KnownGoodOverlays []string `json:"known_good_overlays,omitempty"`
+
+	// Overrides pins modules to a commit, tag or branch:<name> instead of
+	// their known_good entry.
+	Overrides map[string]string `json:"overrides,omitempty"`
+
+	// Local maps modules to local checkouts used with local_path_override,
+	// relative to the project directory.
+	Local map[string]string `json:"local,omitempty"`
 }
 
 const DefaultConfigFileName = "scorex.json"
@@ -20,7 +37,7 @@ func WriteProjectConfig(dir string, cfg *ProjectConfig) error {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	data, err := MarshalProjectConfig(cfg)
 	if err != nil {
 		return err
 	}
@@ -28,6 +45,11 @@ func WriteProjectConfig(dir string, cfg *ProjectConfig) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
+// MarshalProjectConfig returns the scorex.json content for cfg.
+func MarshalProjectConfig(cfg *ProjectConfig) ([]byte, error) {
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
 func ReadProjectConfig(dir string) (*ProjectConfig, error) {
 	path := filepath.Join(dir, DefaultConfigFileName)
 	data, err := os.ReadFile(path)
@@ -40,3 +62,52 @@ func ReadProjectConfig(dir string) (*ProjectConfig, error) {
 	}
 	return &cfg, nil
 }
+
+// ProjectPath turns a local path given relative to the working directory
+// into the form recorded in scorex.json of the project in dir: relative to
+// dir, with forward slashes. URLs and absolute paths are kept as they are.
+func ProjectPath(dir, path string) string {
+	if path == "" || knowngood.IsURL(path) || filepath.IsAbs(path) {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return abs
+	}
+	rel, err := filepath.Rel(absDir, abs)
+	if err != nil {
+		return abs
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ResolveProjectPath turns a path recorded in scorex.json of the project in
+// dir back into one usable from the working directory.
+func ResolveProjectPath(dir, path string) string {
+	if path == "" || knowngood.IsURL(path) || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
+
+// ProjectPaths applies ProjectPath to every path.
+func ProjectPaths(dir string, paths []string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		out = append(out, ProjectPath(dir, p))
+	}
+	return out
+}
+
+// ResolveProjectPaths applies ResolveProjectPath to every path.
+func ResolveProjectPaths(dir string, paths []string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		out = append(out, ResolveProjectPath(dir, p))
+	}
+	return out
+}
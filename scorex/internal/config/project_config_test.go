@@ -0,0 +1,58 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectPath(t *testing.T) {
+	cwd := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(cwd); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	tests := []struct {
+		dir, path, want string
+	}{
+		{"proj", "overlay.json", "../overlay.json"},
+		{"proj", "proj/overlay.json", "overlay.json"},
+		{".", "./overlay.json", "overlay.json"},
+		{"a/proj", "../b/overlay.json", "../../../b/overlay.json"},
+		{"proj", "https://example.com/known_good.json", "https://example.com/known_good.json"},
+		{"proj", "", ""},
+	}
+	for _, tt := range tests {
+		got := ProjectPath(tt.dir, tt.path)
+		if got != tt.want {
+			t.Errorf("ProjectPath(%q, %q) = %q, want %q", tt.dir, tt.path, got, tt.want)
+		}
+		// Resolved from the working directory, it is the same file again.
+		back := ResolveProjectPath(tt.dir, got)
+		if filepath.Clean(back) != filepath.Clean(tt.path) {
+			t.Errorf("ResolveProjectPath(%q, %q) = %q, want %q", tt.dir, got, back, tt.path)
+		}
+	}
+
+	abs := filepath.Join(cwd, "overlay.json")
+	if got := ProjectPath("proj", abs); got != abs {
+		t.Errorf("ProjectPath kept absolute path as %q", got)
+	}
+}
@@ -0,0 +1,26 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package model
+
+// ModuleCatalog describes S-CORE modules beyond their known_good pins.
+type ModuleCatalog struct {
+	Modules map[string]CatalogEntry `json:"modules"`
+}
+
+// CatalogEntry describes a single module of the catalog.
+type CatalogEntry struct {
+	Description  string   `json:"description"`
+	Languages    []string `json:"languages,omitempty"`    // e.g. "C++", "Rust"
+	Targets      []string `json:"targets,omitempty"`      // main Bazel targets, e.g. @score_feo//feo:libfeo_rust
+	Dependencies []string `json:"dependencies,omitempty"` // other S-CORE modules
+}
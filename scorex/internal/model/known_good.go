@@ -2,9 +2,16 @@ package model
 
 // KnownGood represents the structure of known_good.json.
 type KnownGood struct {
+	SchemaVersion  int                   `json:"schema_version,omitempty"` // layout of the file; 0 means 1
 	Timestamp      string                `json:"timestamp"`
 	Modules        map[string]ModuleInfo `json:"modules"`
 	ManifestSHA256 string                `json:"manifest_sha256"`
 	Suite          string                `json:"suite"`
 	DurationS      int                   `json:"duration_s"`
+
+	// ContentSHA256 is the SHA-256 of the file as loaded. It is computed by
+	// knowngood.Load and not part of known_good.json.
+	ContentSHA256 string `json:"-"`
+	// Warnings are problems knowngood.Load found but tolerated.
+	Warnings []string `json:"-"`
 }
@@ -0,0 +1,45 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package model
+
+// Lock represents the structure of scorex.lock.
+type Lock struct {
+	KnownGood LockedKnownGood           `json:"known_good"`
+	Modules   map[string]ResolvedModule `json:"modules"`
+}
+
+// LockedKnownGood identifies the known_good.json a project was resolved against.
+type LockedKnownGood struct {
+	URL            string `json:"url"`
+	SHA256         string `json:"sha256"` // of the known_good.json file that was used
+	ManifestSHA256 string `json:"manifest_sha256"`
+	Timestamp      string `json:"timestamp"`
+	Suite          string `json:"suite,omitempty"`
+
+	// Policy is the policy the manifest was checked against.
+	Policy *LockedPolicy `json:"policy,omitempty"`
+
+	// Overlays are further known_good.json files layered over URL, later
+	// ones overriding earlier ones.
+	Overlays []LockedKnownGood `json:"overlays,omitempty"`
+	// Modules is the effective module set of URL and Overlays; only
+	// recorded if there are overlays.
+	Modules map[string]ModuleInfo `json:"modules,omitempty"`
+}
+
+// LockedPolicy is the known_good policy a project was resolved with.
+type LockedPolicy struct {
+	MaxAge       string `json:"max_age,omitempty"`  // e.g. "14d" or "36h0m0s"
+	OnStale      string `json:"on_stale,omitempty"` // "warn" or "fail"
+	RequireSuite string `json:"require_suite,omitempty"`
+}
@@ -1,8 +1,48 @@
 package model
 
+import "fmt"
+
 type ModuleInfo struct {
 	Version string `json:"version"`
 	Hash    string `json:"hash"`
 	Repo    string `json:"repo"`
 	Branch  string `json:"branch,omitempty"`
+	Pin     *Pin   `json:"pin,omitempty"` // nil means pinned with git_override
+}
+
+// Pin describes how a module is pinned in MODULE.bazel when it is not
+// pinned with git_override.
+type Pin struct {
+	Mode        string `json:"mode"`                   // "registry", "archive" or "local"
+	URL         string `json:"url,omitempty"`          // archive download URL
+	Integrity   string `json:"integrity,omitempty"`    // SRI hash of the archive
+	StripPrefix string `json:"strip_prefix,omitempty"` // top-level directory of the archive
+	Path        string `json:"path,omitempty"`         // local checkout, relative to the project
+}
+
+// PinMode returns how the module is pinned: "git", "registry", "archive" or
+// "local".
+func (m ModuleInfo) PinMode() string {
+	if m.Pin == nil {
+		return "git"
+	}
+	return m.Pin.Mode
+}
+
+// String formats the module like its fields, plus the pin mode if it is not
+// pinned with git_override.
+func (m ModuleInfo) String() string {
+	if m.Pin == nil {
+		return fmt.Sprintf("{%s %s %s %s}", m.Version, m.Hash, m.Repo, m.Branch)
+	}
+	return fmt.Sprintf("{%s %s %s %s %s}", m.Version, m.Hash, m.Repo, m.Branch, m.Pin.Mode)
+}
+
+// ResolvedModule is a module together with where it was resolved from,
+// e.g. "known_good" or the name of a fallback resolver.
+type ResolvedModule struct {
+	ModuleInfo
+	Source     string   `json:"source"`
+	RequiredBy []string `json:"required_by,omitempty"` // set if added as dependency of these modules
+	Warnings   []string `json:"-"`                     // problems found while resolving, for the user
 }
@@ -1,6 +1,10 @@
 package projectinit
 
-import serviceprojectinit "scorex/internal/service/projectinit"
+import (
+    "context"
+
+    serviceprojectinit "scorex/internal/service/projectinit"
+)
 
 // Deprecated: use scorex/internal/service/projectinit.Options.
 type Options = serviceprojectinit.Options
@@ -10,5 +14,5 @@ type Result = serviceprojectinit.Result
 
 // Deprecated: use scorex/internal/service/projectinit.Run.
 func Run(opts Options) (*Result, error) {
-    return serviceprojectinit.Run(opts)
+    return serviceprojectinit.Run(context.Background(), opts)
 }
@@ -0,0 +1,324 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+
+// Package httpfetch is the HTTP layer shared by everything scorex downloads:
+// known_good.json manifests, GitHub API lookups, registry metadata and
+// source archives. It adds proxy and CA configuration, credentials from
+// .netrc and GITHUB_TOKEN, and retries with backoff that respect GitHub's
+// rate-limit headers.
+package httpfetch
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of Config.
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+	DefaultBackoff = 500 * time.Millisecond
+	DefaultMaxWait = time.Minute
+)
+
+// githubHosts receive GITHUB_TOKEN as bearer token.
+var githubHosts = []string{"api.github.com", "raw.githubusercontent.com"}
+
+// Config configures a Client. The zero value uses the defaults and the
+// environment.
+type Config struct {
+	Timeout time.Duration // per request, including reading the body; defaults to DefaultTimeout
+
+	// Proxy is the URL of the HTTP(S) proxy. When empty, HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY apply.
+	Proxy string
+	// CAFile is a PEM file with additional trusted CA certificates, e.g. of
+	// a TLS-intercepting corporate proxy.
+	CAFile string
+
+	// NetrcFile holds credentials per host. Defaults to $NETRC or ~/.netrc;
+	// a missing default file is ignored.
+	NetrcFile string
+	// GitHubToken is sent as bearer token to GitHub. Defaults to
+	// $GITHUB_TOKEN.
+	GitHubToken string
+
+	Retries int           // retries of failed requests; defaults to DefaultRetries, negative disables them
+	Backoff time.Duration // delay before the first retry, doubled for each further one; defaults to DefaultBackoff
+	MaxWait time.Duration // longest wait for Retry-After or a rate-limit reset; defaults to DefaultMaxWait
+}
+
+// Client performs GET requests according to its Config. It is safe for
+// concurrent use. A nil *Client uses Default().
+type Client struct {
+	http  *http.Client
+	cfg   Config
+	netrc map[string]netrcEntry
+	err   error // returned by every request of a client that could not be set up
+}
+
+var (
+	defaultMu     sync.Mutex
+	defaultClient *Client
+	defaultConfig *Config
+)
+
+// Default returns the client set with SetDefault or configured with
+// Configure, or else one configured from the environment.
+func Default() *Client {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultClient != nil {
+		return defaultClient
+	}
+	if defaultConfig != nil {
+		c, err := New(*defaultConfig)
+		if err != nil {
+			c = &Client{err: fmt.Errorf("setting up HTTP: %w", err)}
+		}
+		defaultClient = c
+		return c
+	}
+	c, err := New(Config{})
+	if err != nil {
+		// Only a broken ~/.netrc gets here; go on without it.
+		c, _ = New(Config{NetrcFile: os.DevNull})
+	}
+	defaultClient = c
+	return c
+}
+
+// SetDefault replaces the client returned by Default. A nil c makes Default
+// configure one from the environment again.
+func SetDefault(c *Client) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = c
+	defaultConfig = nil
+}
+
+// Configure makes Default return a client for cfg. The client is only
+// created when Default is first called, so an unreadable CA or netrc file
+// only fails the downloads, not commands that make no request.
+func Configure(cfg Config) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultClient = nil
+	defaultConfig = &cfg
+}
+
+// New creates a client for cfg.
+func New(cfg Config) (*Client, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Retries == 0 {
+		cfg.Retries = DefaultRetries
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = DefaultBackoff
+	}
+	if cfg.MaxWait == 0 {
+		cfg.MaxWait = DefaultMaxWait
+	}
+	if cfg.GitHubToken == "" {
+		cfg.GitHubToken = os.Getenv("GITHUB_TOKEN")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if cfg.CAFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificates: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates in %s", cfg.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	netrc, err := loadNetrc(cfg.NetrcFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		http:  &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		cfg:   cfg,
+		netrc: netrc,
+	}, nil
+}
+
+// WithTimeout returns a copy of c whose requests may take up to d, if that
+// is longer than c's timeout; e.g. for large downloads.
+func (c *Client) WithTimeout(d time.Duration) *Client {
+	if c == nil {
+		c = Default()
+	}
+	if c.err != nil || d <= c.http.Timeout {
+		return c
+	}
+	cp := *c
+	hc := *c.http
+	hc.Timeout = d
+	cp.http = &hc
+	return &cp
+}
+
+// Get requests rawURL with the given header, see Do. Cancelling ctx aborts
+// the request and any wait for a retry.
+func (c *Client) Get(ctx context.Context, rawURL string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	return c.Do(req)
+}
+
+// Do sends req, which must not have a body. Unless req carries an
+// Authorization header, GitHub hosts get the GitHub token and other hosts
+// their .netrc credentials. Network errors, 429 and 5xx responses are
+// retried with exponential backoff; Retry-After and exhausted GitHub rate
+// limits are waited out if that takes at most Config.MaxWait. The caller
+// closes the response body.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c == nil {
+		c = Default()
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.authorize(req)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.http.Do(req.Clone(req.Context()))
+		if req.Context().Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+
+		wait, retry := c.retryAfter(resp, err, attempt)
+		if !retry {
+			if err == nil {
+				if rl := rateLimited(resp); rl != nil {
+					resp.Body.Close()
+					return nil, rl
+				}
+			}
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, req.Context().Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Client) authorize(req *http.Request) {
+	if req.Header.Get("Authorization") != "" {
+		return
+	}
+	host := req.URL.Hostname()
+	if c.cfg.GitHubToken != "" && isGitHubHost(host) {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.GitHubToken)
+		return
+	}
+	if e, ok := c.netrc[host]; ok {
+		req.SetBasicAuth(e.Login, e.Password)
+	} else if e, ok := c.netrc[""]; ok {
+		req.SetBasicAuth(e.Login, e.Password)
+	}
+}
+
+// retryAfter decides whether to retry after attempt and how long to wait.
+func (c *Client) retryAfter(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= c.cfg.Retries {
+		return 0, false
+	}
+	backoff := c.cfg.Backoff << attempt
+	if err != nil {
+		return backoff, true
+	}
+
+	if rl := rateLimited(resp); rl != nil {
+		wait := time.Until(rl.Reset)
+		return wait, wait <= c.cfg.MaxWait
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait, wait <= c.cfg.MaxWait
+		}
+		return backoff, true
+	}
+	return 0, false
+}
+
+func isGitHubHost(host string) bool {
+	for _, h := range githubHosts {
+		if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	var secs int
+	if _, err := fmt.Sscanf(v, "%d", &secs); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,438 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package httpfetch
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client that ignores the user's .netrc and
+// GITHUB_TOKEN and retries quickly.
+func newTestClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	if cfg.NetrcFile == "" {
+		cfg.NetrcFile = os.DevNull
+	}
+	if cfg.GitHubToken == "" {
+		cfg.GitHubToken = "test-token"
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = time.Millisecond
+	}
+	c, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// failingServer answers the first n requests with status and header, then
+// with 200.
+func failingServer(t *testing.T, n int, status int, header http.Header) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if int(calls.Add(1)) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRetry(t *testing.T) {
+	for _, status := range []int{
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout, http.StatusTooManyRequests,
+	} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			srv, calls := failingServer(t, 2, status, nil)
+			resp, err := newTestClient(t, Config{}).Get(context.Background(), srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("status = %d, want 200", resp.StatusCode)
+			}
+			if got := calls.Load(); got != 3 {
+				t.Errorf("server saw %d requests, want 3", got)
+			}
+		})
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	srv, calls := failingServer(t, 10, http.StatusServiceUnavailable, nil)
+	resp, err := newTestClient(t, Config{Retries: 2}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server saw %d requests, want 3", got)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	srv, calls := failingServer(t, 1, http.StatusNotFound, nil)
+	resp, err := newTestClient(t, Config{}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": {"1"}}
+	srv, calls := failingServer(t, 1, http.StatusServiceUnavailable, header)
+
+	start := time.Now()
+	resp, err := newTestClient(t, Config{}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("status = %d after %d requests, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s of Retry-After", elapsed)
+	}
+}
+
+func TestRetryAfterBeyondMaxWait(t *testing.T) {
+	header := http.Header{"Retry-After": {"3600"}}
+	srv, calls := failingServer(t, 1, http.StatusTooManyRequests, header)
+
+	resp, err := newTestClient(t, Config{MaxWait: time.Second}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", resp.StatusCode)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		in      string
+		ok      bool
+		atLeast time.Duration
+	}{
+		{"", false, 0},
+		{"soon", false, 0},
+		{"0", true, 0},
+		{"120", true, 2 * time.Minute},
+		{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), true, 59 * time.Minute},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), true, 0},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in)
+		if ok != tt.ok || got < tt.atLeast || got > tt.atLeast+time.Minute {
+			t.Errorf("parseRetryAfter(%q) = %s, %v; want >= %s, %v", tt.in, got, ok, tt.atLeast, tt.ok)
+		}
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Unix()
+	header := http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {strconv.FormatInt(reset, 10)},
+	}
+	srv, calls := failingServer(t, 10, http.StatusForbidden, header)
+
+	_, err := newTestClient(t, Config{MaxWait: time.Second}).Get(context.Background(), srv.URL, nil)
+	var rl *RateLimitError
+	if !errors.As(err, &rl) {
+		t.Fatalf("err = %v, want *RateLimitError", err)
+	}
+	if rl.Reset.Unix() != reset {
+		t.Errorf("Reset = %v, want %v", rl.Reset.Unix(), reset)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestRateLimitWaitedOut(t *testing.T) {
+	header := http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {strconv.FormatInt(time.Now().Unix(), 10)},
+	}
+	srv, calls := failingServer(t, 1, http.StatusForbidden, header)
+
+	resp, err := newTestClient(t, Config{}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("status = %d after %d requests, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestForbiddenWithoutRateLimit(t *testing.T) {
+	header := http.Header{"X-Ratelimit-Remaining": {"42"}}
+	srv, _ := failingServer(t, 1, http.StatusForbidden, header)
+
+	resp, err := newTestClient(t, Config{}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", resp.StatusCode)
+	}
+}
+
+func TestCancelDuringBackoff(t *testing.T) {
+	srv, _ := failingServer(t, 10, http.StatusServiceUnavailable, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := newTestClient(t, Config{Backoff: time.Hour}).Get(ctx, srv.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestGitHubTokenOnlyForGitHubHosts(t *testing.T) {
+	c := newTestClient(t, Config{GitHubToken: "secret"})
+
+	for _, u := range []string{
+		"https://api.github.com/repos/eclipse-score/baselibs",
+		"https://raw.githubusercontent.com/eclipse-score/baselibs/main/MODULE.bazel",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, u, nil)
+		c.authorize(req)
+		if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("%s: Authorization = %q, want bearer token", u, got)
+		}
+	}
+
+	for _, u := range []string{
+		"https://github.com.example.com/",
+		"https://bcr.bazel.build/modules/score_baselibs/metadata.json",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, u, nil)
+		c.authorize(req)
+		if got := req.Header.Get("Authorization"); got != "" {
+			t.Errorf("%s: Authorization = %q, want none", u, got)
+		}
+	}
+
+	// The local server is no GitHub host either.
+	var got atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Store(r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+	resp, err := c.Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if auth := got.Load().(string); auth != "" {
+		t.Errorf("local server got Authorization %q, want none", auth)
+	}
+}
+
+func TestExplicitAuthorizationKept(t *testing.T) {
+	c := newTestClient(t, Config{GitHubToken: "secret"})
+	req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/", nil)
+	req.Header.Set("Authorization", "token other")
+	c.authorize(req)
+	if got := req.Header.Get("Authorization"); got != "token other" {
+		t.Errorf("Authorization = %q, want the caller's", got)
+	}
+}
+
+func TestNetrcBasicAuth(t *testing.T) {
+	type creds struct{ user, pass string }
+	var got atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		got.Store(creds{user, pass})
+	}))
+	defer srv.Close()
+
+	fetch := func(netrc string) creds {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "netrc")
+		if err := os.WriteFile(path, []byte(netrc), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := newTestClient(t, Config{NetrcFile: path}).Get(context.Background(), srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return got.Load().(creds)
+	}
+
+	if c := fetch("machine 127.0.0.1 login alice password s3cret\ndefault login anon password guest\n"); c != (creds{"alice", "s3cret"}) {
+		t.Errorf("machine entry: got %+v", c)
+	}
+	if c := fetch("machine example.com login alice password s3cret\ndefault login anon password guest\n"); c != (creds{"anon", "guest"}) {
+		t.Errorf("default entry: got %+v", c)
+	}
+	if c := fetch("machine example.com login alice password s3cret\n"); c != (creds{}) {
+		t.Errorf("no matching entry: got %+v", c)
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	content := `# credentials
+machine example.com
+  login alice
+  password s3cret
+  account ignored
+
+macdef init
+machine evil.example.com login mallory password nope
+cd /tmp
+
+machine registry.example.com login bob password hunter2 # trailing comment
+default login anon password guest
+`
+	got, err := parseNetrc(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]netrcEntry{
+		"example.com":          {Login: "alice", Password: "s3cret"},
+		"registry.example.com": {Login: "bob", Password: "hunter2"},
+		"":                     {Login: "anon", Password: "guest"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("parseNetrc() = %v, want %v", got, want)
+	}
+	for k, w := range want {
+		if got[k] != w {
+			t.Errorf("entry %q = %+v, want %+v", k, got[k], w)
+		}
+	}
+}
+
+func TestParseNetrcFirstMachineWins(t *testing.T) {
+	got, err := parseNetrc("machine h login a password 1\nmachine h login b password 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["h"] != (netrcEntry{Login: "a", Password: "1"}) {
+		t.Errorf("entry = %+v, want the first one", got["h"])
+	}
+}
+
+func TestParseNetrcMissingValue(t *testing.T) {
+	if _, err := parseNetrc("machine example.com login"); err == nil {
+		t.Error("expected an error for login without value")
+	}
+}
+
+func TestLoadNetrcExplicitMissing(t *testing.T) {
+	if _, err := loadNetrc(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for an explicit netrc file that does not exist")
+	}
+}
+
+func TestCAFile(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	// Without the server's CA the request fails.
+	if _, err := newTestClient(t, Config{Retries: -1}).Get(context.Background(), srv.URL, nil); err == nil {
+		t.Fatal("expected a certificate error without CA file")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(block), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := newTestClient(t, Config{CAFile: caFile}).Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestCAFileInvalid(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(Config{CAFile: caFile, NetrcFile: os.DevNull}); err == nil {
+		t.Error("expected an error for a CA file without certificates")
+	}
+	if _, err := New(Config{CAFile: caFile + ".missing", NetrcFile: os.DevNull}); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+}
+
+func TestConfigureIsLazy(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+	srv, calls := failingServer(t, 0, http.StatusOK, nil)
+
+	// A broken setting must not fail before the first download.
+	Configure(Config{CAFile: filepath.Join(t.TempDir(), "missing.pem"), NetrcFile: os.DevNull})
+	_, err := Default().Get(context.Background(), srv.URL, nil)
+	if err == nil {
+		t.Fatal("Get() with a missing CA file succeeded")
+	}
+	if calls.Load() != 0 {
+		t.Errorf("server got %d requests, want none", calls.Load())
+	}
+
+	Configure(Config{NetrcFile: os.DevNull})
+	resp, err := Default().Get(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatalf("Get() after fixing the configuration failed: %v", err)
+	}
+	resp.Body.Close()
+}
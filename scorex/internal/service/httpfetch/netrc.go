@@ -0,0 +1,118 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package httpfetch
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type netrcEntry struct {
+	Login    string
+	Password string
+}
+
+// loadNetrc reads the credentials of path, keyed by machine name; the
+// default entry has the key "". Without an explicit path, $NETRC or
+// ~/.netrc is used if it exists.
+func loadNetrc(path string) (map[string]netrcEntry, error) {
+	explicit := path != ""
+	if !explicit {
+		path = os.Getenv("NETRC")
+		if path == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, nil
+			}
+			path = filepath.Join(home, ".netrc")
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading netrc: %w", err)
+	}
+	return parseNetrc(string(data))
+}
+
+// parseNetrc parses the machine, default, login and password tokens of a
+// .netrc file. macdef bodies, which end at an empty line, are skipped.
+func parseNetrc(content string) (map[string]netrcEntry, error) {
+	entries := make(map[string]netrcEntry)
+	var machine *string
+	var current netrcEntry
+	flush := func() {
+		if machine != nil {
+			if _, ok := entries[*machine]; !ok {
+				entries[*machine] = current
+			}
+		}
+		current = netrcEntry{}
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		fields := strings.Fields(lines[i])
+		for j := 0; j < len(fields); j++ {
+			tok := fields[j]
+			if strings.HasPrefix(tok, "#") {
+				break
+			}
+			next := func() (string, error) {
+				if j+1 >= len(fields) {
+					return "", fmt.Errorf("netrc line %d: %s without value", i+1, tok)
+				}
+				j++
+				return fields[j], nil
+			}
+			switch tok {
+			case "machine":
+				flush()
+				name, err := next()
+				if err != nil {
+					return nil, err
+				}
+				machine = &name
+			case "default":
+				flush()
+				name := ""
+				machine = &name
+			case "login", "password", "account":
+				v, err := next()
+				if err != nil {
+					return nil, err
+				}
+				if tok == "login" {
+					current.Login = v
+				} else if tok == "password" {
+					current.Password = v
+				}
+			case "macdef":
+				// skip the macro up to the next empty line
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+					i++
+				}
+				j = len(fields)
+			}
+		}
+	}
+	flush()
+	return entries, nil
+}
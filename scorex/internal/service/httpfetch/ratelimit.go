@@ -0,0 +1,47 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package httpfetch
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError reports an exhausted GitHub rate limit.
+type RateLimitError struct {
+	URL   string
+	Reset time.Time // when requests are allowed again
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("GitHub rate limit exceeded for %s until %s (set GITHUB_TOKEN for a higher limit)",
+		e.URL, e.Reset.Local().Format("15:04:05"))
+}
+
+// rateLimited returns a RateLimitError if resp is GitHub's answer to an
+// exhausted rate limit: 403 or 429 with X-RateLimit-Remaining: 0.
+func rateLimited(resp *http.Response) *RateLimitError {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return nil
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &RateLimitError{URL: resp.Request.URL.Redacted(), Reset: time.Unix(reset, 0)}
+}
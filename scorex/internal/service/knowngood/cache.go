@@ -0,0 +1,144 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheDir returns the directory scorex caches downloads in,
+// e.g. ~/.cache/scorex on Linux.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scorex"), nil
+}
+
+// cache stores fetched known_good.json files by URL, together with the ETag
+// the server sent, so unchanged manifests are not downloaded again and
+// offline runs can use the last copy.
+type cache struct {
+	dir string
+}
+
+type cacheMeta struct {
+	URL       string    `json:"url"`
+	ETag      string    `json:"etag,omitempty"`
+	FetchedAt time.Time `json:"fetched_at"`
+	SHA256    string    `json:"sha256"` // of the cached data, to detect a torn update
+}
+
+type cacheEntry struct {
+	cacheMeta
+	Data []byte
+}
+
+// openCache returns the cache below dir, or below DefaultCacheDir if dir is
+// empty. It returns nil if no cache directory can be determined.
+func openCache(dir string) *cache {
+	if dir == "" {
+		d, err := DefaultCacheDir()
+		if err != nil {
+			return nil
+		}
+		dir = d
+	}
+	return &cache{dir: filepath.Join(dir, "known_good")}
+}
+
+func (c *cache) key(url string) string {
+	return filepath.Join(c.dir, sumSHA256([]byte(url)))
+}
+
+// get returns the cached entry for url; the error wraps fs.ErrNotExist if
+// there is none.
+func (c *cache) get(url string) (*cacheEntry, error) {
+	key := c.key(url)
+	metaData, err := os.ReadFile(key + ".meta.json")
+	if err != nil {
+		return nil, err
+	}
+	var meta cacheMeta
+	if err := json.Unmarshal(metaData, &meta); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(key + ".json")
+	if err != nil {
+		return nil, err
+	}
+	// Entries written before the checksum was recorded have none.
+	if meta.SHA256 != "" && sumSHA256(data) != meta.SHA256 {
+		return nil, fmt.Errorf("cached copy of %s does not match its metadata: %w", url, fs.ErrNotExist)
+	}
+	return &cacheEntry{cacheMeta: meta, Data: data}, nil
+}
+
+// put stores data and its metadata. Each file is replaced atomically; if a
+// run is interrupted between the two, get no longer finds a matching entry.
+func (c *cache) put(url, etag string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	meta, err := json.MarshalIndent(cacheMeta{
+		URL:       url,
+		ETag:      etag,
+		FetchedAt: time.Now().UTC(),
+		SHA256:    sumSHA256(data),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	key := c.key(url)
+	if err := WriteFileAtomic(key+".json", data); err != nil {
+		return err
+	}
+	return WriteFileAtomic(key+".meta.json", meta)
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames
+// it into place, so readers see either the old or the new content.
+func WriteFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+func sumSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
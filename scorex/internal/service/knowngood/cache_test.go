@@ -0,0 +1,83 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testURL = "https://example.com/known_good.json"
+
+func TestCachePutGet(t *testing.T) {
+	c := openCache(t.TempDir())
+	if _, err := c.get(testURL); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("get on empty cache: err = %v, want fs.ErrNotExist", err)
+	}
+
+	for _, content := range []string{"first", "second"} {
+		if err := c.put(testURL, `"etag-`+content+`"`, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		e, err := c.get(testURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(e.Data) != content || e.ETag != `"etag-`+content+`"` {
+			t.Errorf("get = %q with ETag %s, want %q", e.Data, e.ETag, content)
+		}
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("cache holds %d files, want data and metadata only", len(entries))
+	}
+}
+
+func TestCacheTornUpdate(t *testing.T) {
+	c := openCache(t.TempDir())
+	if err := c.put(testURL, `"old"`, []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	// An interrupted put that replaced the data but not the metadata.
+	if err := WriteFileAtomic(c.key(testURL)+".json", []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.get(testURL); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("get after torn update: err = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestWriteFileAtomicLeavesNoTempFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	if err := WriteFileAtomic(path, []byte("content")); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "file"), nil); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries, want 1", len(entries))
+	}
+}
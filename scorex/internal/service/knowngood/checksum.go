@@ -0,0 +1,120 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ChecksumSuffix is appended to a manifest URL or path to find its detached
+// checksum file, in the format written by sha256sum.
+const ChecksumSuffix = ".sha256"
+
+var sha256Hex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ChecksumError reports a manifest whose SHA-256 does not match the expected one.
+type ChecksumError struct {
+	Source   string
+	Expected string
+	Actual   string
+	From     string // where the expected value came from
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch for %s: expected sha256 %s (%s), got %s",
+		e.Source, e.Expected, e.From, e.Actual)
+}
+
+// NormalizeSHA256 validates a hex SHA-256 digest and returns it in lower case.
+func NormalizeSHA256(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if !sha256Hex.MatchString(s) {
+		return "", fmt.Errorf("invalid sha256 %q (expected 64 hex characters)", s)
+	}
+	return s, nil
+}
+
+// verify checks data against the expected checksum and returns its SHA-256.
+func verify(ctx context.Context, urlOrPath string, data []byte, opts LoadOptions) (string, error) {
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+
+	expected, from := opts.ExpectedSHA256, "pinned"
+	if expected == "" {
+		detached, err := loadDetachedChecksum(ctx, urlOrPath, opts)
+		if err != nil {
+			return "", err
+		}
+		expected, from = detached, urlOrPath+ChecksumSuffix
+	}
+	if expected == "" {
+		if opts.RequireChecksum {
+			return "", fmt.Errorf("no checksum available for %s (pass --known-good-sha256 or publish %s%s)",
+				urlOrPath, urlOrPath, ChecksumSuffix)
+		}
+		return actual, nil
+	}
+
+	expected, err := NormalizeSHA256(expected)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", from, err)
+	}
+	if expected != actual {
+		return "", &ChecksumError{Source: urlOrPath, Expected: expected, Actual: actual, From: from}
+	}
+	return actual, nil
+}
+
+// loadDetachedChecksum returns the digest from "<urlOrPath>.sha256", or an
+// empty string if there is none. In offline mode a checksum file that was
+// never cached counts as missing.
+func loadDetachedChecksum(ctx context.Context, urlOrPath string, opts LoadOptions) (string, error) {
+	var data []byte
+	var err error
+	if IsURL(urlOrPath) {
+		data, err = fetch(ctx, urlOrPath+ChecksumSuffix, opts)
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		// Most manifests come without a checksum file. Only a missing file
+		// means there is none; any other failure could hide a published
+		// checksum and skip verification.
+		if errors.Is(err, errNotFound) || errors.Is(err, errNotCached) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("fetching %s%s: %w", urlOrPath, ChecksumSuffix, err)
+		}
+	} else {
+		data, err = os.ReadFile(urlOrPath + ChecksumSuffix)
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%s%s is empty", urlOrPath, ChecksumSuffix)
+	}
+	return fields[0], nil
+}
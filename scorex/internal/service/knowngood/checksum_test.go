@@ -0,0 +1,122 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"scorex/internal/service/httpfetch"
+)
+
+const testManifest = `{
+  "timestamp": "2026-01-01T00:00:00Z",
+  "modules": {
+    "score_baselibs": {
+      "version": "0.1.0",
+      "hash": "0123456789abcdef0123456789abcdef01234567",
+      "repo": "https://github.com/eclipse-score/baselibs.git"
+    }
+  }
+}`
+
+// serveManifest serves testManifest at /known_good.json and answers
+// /known_good.json.sha256 with checksumStatus and checksum.
+func serveManifest(t *testing.T, checksumStatus int, checksum string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/known_good.json":
+			w.Write([]byte(testManifest))
+		case "/known_good.json" + ChecksumSuffix:
+			w.WriteHeader(checksumStatus)
+			w.Write([]byte(checksum))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/known_good.json"
+}
+
+func testLoadOptions(t *testing.T) LoadOptions {
+	t.Helper()
+	c, err := httpfetch.New(httpfetch.Config{Retries: -1, NetrcFile: os.DevNull})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return LoadOptions{CacheDir: t.TempDir(), Client: c}
+}
+
+func manifestSHA256() string {
+	sum := sha256.Sum256([]byte(testManifest))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDetachedChecksumMissing(t *testing.T) {
+	url := serveManifest(t, http.StatusNotFound, "")
+	kg, err := LoadWithOptions(context.Background(), url, testLoadOptions(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kg.ContentSHA256 != manifestSHA256() {
+		t.Errorf("ContentSHA256 = %s, want %s", kg.ContentSHA256, manifestSHA256())
+	}
+
+	opts := testLoadOptions(t)
+	opts.RequireChecksum = true
+	if _, err := LoadWithOptions(context.Background(), url, opts); err == nil {
+		t.Error("expected an error with RequireChecksum and no checksum file")
+	}
+}
+
+func TestDetachedChecksumMatch(t *testing.T) {
+	url := serveManifest(t, http.StatusOK, manifestSHA256()+"  known_good.json\n")
+	if _, err := LoadWithOptions(context.Background(), url, testLoadOptions(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetachedChecksumMismatch(t *testing.T) {
+	other := sha256.Sum256([]byte("tampered"))
+	url := serveManifest(t, http.StatusOK, hex.EncodeToString(other[:]))
+	_, err := LoadWithOptions(context.Background(), url, testLoadOptions(t))
+	var ce *ChecksumError
+	if !errors.As(err, &ce) {
+		t.Fatalf("err = %v, want *ChecksumError", err)
+	}
+}
+
+func TestDetachedChecksumFetchError(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusForbidden, http.StatusBadGateway} {
+		url := serveManifest(t, status, "")
+		if _, err := LoadWithOptions(context.Background(), url, testLoadOptions(t)); err == nil {
+			t.Errorf("HTTP %d for the checksum file: expected an error, verification was skipped", status)
+		}
+	}
+}
+
+func TestPinnedChecksumWins(t *testing.T) {
+	url := serveManifest(t, http.StatusInternalServerError, "")
+	opts := testLoadOptions(t)
+	opts.ExpectedSHA256 = manifestSHA256()
+	if _, err := LoadWithOptions(context.Background(), url, opts); err != nil {
+		t.Fatal(err)
+	}
+}
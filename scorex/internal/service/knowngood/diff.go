@@ -0,0 +1,109 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"regexp"
+	"sort"
+
+	"scorex/internal/model"
+)
+
+// githubRepo matches GitHub repository URLs, capturing owner/repo.
+var githubRepo = regexp.MustCompile(`^(?:https://|git@)github\.com[/:]([^/]+/[^/]+?)(?:\.git)?/?$`)
+
+// ModuleEntry is a module added to or removed from a manifest.
+type ModuleEntry struct {
+	Name string `json:"name"`
+	model.ModuleInfo
+}
+
+// ModuleChange is a module whose entry differs between two manifests.
+type ModuleChange struct {
+	Name string           `json:"name"`
+	Old  model.ModuleInfo `json:"old"`
+	New  model.ModuleInfo `json:"new"`
+
+	// CompareURL shows the commits between Old and New, if both are in the
+	// same GitHub repository.
+	CompareURL string `json:"compare_url,omitempty"`
+}
+
+func (c ModuleChange) VersionChanged() bool { return c.Old.Version != c.New.Version }
+func (c ModuleChange) HashChanged() bool    { return c.Old.Hash != c.New.Hash }
+func (c ModuleChange) RepoChanged() bool    { return c.Old.Repo != c.New.Repo }
+func (c ModuleChange) BranchChanged() bool  { return c.Old.Branch != c.New.Branch }
+
+// ManifestDiff lists the differences between two manifests, each sorted by
+// module name.
+type ManifestDiff struct {
+	Added   []ModuleEntry  `json:"added"`
+	Removed []ModuleEntry  `json:"removed"`
+	Changed []ModuleChange `json:"changed"`
+}
+
+// Empty reports whether both manifests pin the same modules.
+func (d *ManifestDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares the modules of the manifests from and to.
+func Diff(from, to *model.KnownGood) *ManifestDiff {
+	d := &ManifestDiff{
+		Added:   []ModuleEntry{},
+		Removed: []ModuleEntry{},
+		Changed: []ModuleChange{},
+	}
+	for _, name := range sortedModules(to) {
+		mi := to.Modules[name]
+		prev, ok := from.Modules[name]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, ModuleEntry{Name: name, ModuleInfo: mi})
+		case !samePin(prev, mi):
+			d.Changed = append(d.Changed, ModuleChange{
+				Name:       name,
+				Old:        prev,
+				New:        mi,
+				CompareURL: compareURL(prev, mi),
+			})
+		}
+	}
+	for _, name := range sortedModules(from) {
+		if _, ok := to.Modules[name]; !ok {
+			d.Removed = append(d.Removed, ModuleEntry{Name: name, ModuleInfo: from.Modules[name]})
+		}
+	}
+	return d
+}
+
+func compareURL(from, to model.ModuleInfo) string {
+	if from.Hash == to.Hash || from.Hash == "" || to.Hash == "" {
+		return ""
+	}
+	f := githubRepo.FindStringSubmatch(from.Repo)
+	t := githubRepo.FindStringSubmatch(to.Repo)
+	if f == nil || t == nil || f[1] != t[1] {
+		return ""
+	}
+	return "https://github.com/" + f[1] + "/compare/" + from.Hash + "..." + to.Hash
+}
+
+func sortedModules(kg *model.KnownGood) []string {
+	names := make([]string, 0, len(kg.Modules))
+	for name := range kg.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
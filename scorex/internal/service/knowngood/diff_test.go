@@ -0,0 +1,92 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"encoding/json"
+	"testing"
+
+	"scorex/internal/model"
+)
+
+func TestDiff(t *testing.T) {
+	const repo = "https://github.com/eclipse-score/baselibs.git"
+	from := &model.KnownGood{Modules: map[string]model.ModuleInfo{
+		"score_baselibs":  {Version: "1.0.0", Hash: "aaa", Repo: repo},
+		"score_same":      {Version: "1.0.0", Hash: "sss", Repo: "https://example.com/same.git"},
+		"score_removed_b": {Version: "0.2.0", Hash: "rb"},
+		"score_removed_a": {Version: "0.1.0", Hash: "ra"},
+		"score_moved":     {Version: "1.0.0", Hash: "mmm", Repo: "https://github.com/eclipse-score/moved.git"},
+		"score_branch":    {Version: "1.0.0", Hash: "bbb", Repo: repo},
+	}}
+	to := &model.KnownGood{Modules: map[string]model.ModuleInfo{
+		"score_baselibs": {Version: "1.1.0", Hash: "ccc", Repo: "git@github.com:eclipse-score/baselibs"},
+		"score_same":     {Version: "1.0.0", Hash: "sss", Repo: "https://example.com/same.git"},
+		"score_added_b":  {Version: "0.2.0", Hash: "ab"},
+		"score_added_a":  {Version: "0.1.0", Hash: "aa"},
+		"score_moved":    {Version: "1.0.0", Hash: "nnn", Repo: "https://github.com/other/moved.git"},
+		"score_branch":   {Version: "1.0.0", Hash: "bbb", Repo: repo, Branch: "release"},
+	}}
+
+	d := Diff(from, to)
+	if d.Empty() {
+		t.Fatal("Diff() is empty")
+	}
+	if len(d.Added) != 2 || d.Added[0].Name != "score_added_a" || d.Added[1].Name != "score_added_b" || d.Added[0].Hash != "aa" {
+		t.Errorf("added = %+v, want score_added_a and score_added_b", d.Added)
+	}
+	if len(d.Removed) != 2 || d.Removed[0].Name != "score_removed_a" || d.Removed[1].Name != "score_removed_b" || d.Removed[0].Version != "0.1.0" {
+		t.Errorf("removed = %+v, want score_removed_a and score_removed_b", d.Removed)
+	}
+	if len(d.Changed) != 3 {
+		t.Fatalf("changed = %+v, want score_baselibs, score_branch and score_moved", d.Changed)
+	}
+
+	baselibs := d.Changed[0]
+	if baselibs.Name != "score_baselibs" || !baselibs.VersionChanged() || !baselibs.HashChanged() || !baselibs.RepoChanged() || baselibs.BranchChanged() {
+		t.Errorf("score_baselibs change = %+v", baselibs)
+	}
+	// Both URLs name the same GitHub repository.
+	if want := "https://github.com/eclipse-score/baselibs/compare/aaa...ccc"; baselibs.CompareURL != want {
+		t.Errorf("score_baselibs compare URL = %q, want %q", baselibs.CompareURL, want)
+	}
+
+	branch := d.Changed[1]
+	if branch.Name != "score_branch" || !branch.BranchChanged() || branch.HashChanged() || branch.CompareURL != "" {
+		t.Errorf("score_branch change = %+v, want only the branch changed", branch)
+	}
+
+	moved := d.Changed[2]
+	if moved.Name != "score_moved" || moved.CompareURL != "" {
+		t.Errorf("score_moved change = %+v, want no compare URL across repositories", moved)
+	}
+}
+
+func TestDiffEmpty(t *testing.T) {
+	kg := &model.KnownGood{Modules: map[string]model.ModuleInfo{
+		"score_baselibs": {Version: "1.0.0", Hash: "aaa"},
+	}}
+	d := Diff(kg, kg)
+	if !d.Empty() {
+		t.Errorf("Diff() of the same manifest = %+v, want it empty", d)
+	}
+
+	// The JSON output lists empty sections rather than null.
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"added":[],"removed":[],"changed":[]}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
@@ -1,7 +1,8 @@
 package knowngood
 
 import (
-    "encoding/json"
+    "context"
+    "errors"
     "fmt"
     "io"
     "net/http"
@@ -9,39 +10,117 @@ import (
     "strings"
 
     "scorex/internal/model"
+    "scorex/internal/service/httpfetch"
 )
 
+var (
+    // errNotFound is returned by fetch for an HTTP 404.
+    errNotFound = errors.New("HTTP 404 Not Found")
+    // errNotCached is returned by fetch in offline mode without a cached copy.
+    errNotCached = errors.New("no cached copy")
+)
+
+// LoadOptions controls how known_good.json files are fetched.
+type LoadOptions struct {
+    Offline  bool   // only use cached copies of remote manifests, never the network
+    CacheDir string // defaults to DefaultCacheDir()
+
+    // ExpectedSHA256 is the hex SHA-256 the manifest must have. When empty,
+    // a detached "<urlOrPath>.sha256" is used if one exists.
+    ExpectedSHA256 string
+    // RequireChecksum fails loading when no expected checksum is available.
+    RequireChecksum bool
+
+    Client *httpfetch.Client // downloads remote manifests, defaults to httpfetch.Default()
+}
+
 // Load loads a KnownGood specification from a local file or HTTP(S) URL.
-func Load(urlOrPath string) (*model.KnownGood, error) {
-    var data []byte
-    var err error
-
-    if strings.HasPrefix(urlOrPath, "http://") || strings.HasPrefix(urlOrPath, "https://") {
-        resp, err := http.Get(urlOrPath)
-        if err != nil {
-            return nil, err
-        }
-        defer resp.Body.Close()
+func Load(ctx context.Context, urlOrPath string) (*model.KnownGood, error) {
+    return LoadWithOptions(ctx, urlOrPath, LoadOptions{})
+}
 
-        if resp.StatusCode != http.StatusOK {
-            return nil, fmt.Errorf("HTTP %s", resp.Status)
-        }
+// LoadWithOptions works like Load. Remote manifests are cached; with
+// opts.Offline they are only read from the cache.
+func LoadWithOptions(ctx context.Context, urlOrPath string, opts LoadOptions) (*model.KnownGood, error) {
+    data, err := ReadSource(ctx, urlOrPath, opts)
+    if err != nil {
+        return nil, err
+    }
 
-        data, err = io.ReadAll(resp.Body)
-        if err != nil {
-            return nil, err
-        }
-    } else {
-        data, err = os.ReadFile(urlOrPath)
-        if err != nil {
-            return nil, err
+    sum, err := verify(ctx, urlOrPath, data, opts)
+    if err != nil {
+        return nil, err
+    }
+
+    kg, err := Parse(data)
+    if err != nil {
+        return nil, fmt.Errorf("%s: %w", urlOrPath, err)
+    }
+    kg.ContentSHA256 = sum
+
+    return kg, nil
+}
+
+// ReadSource reads a local file, or fetches an HTTP(S) URL through the cache
+// like LoadWithOptions does. The content is not verified.
+func ReadSource(ctx context.Context, urlOrPath string, opts LoadOptions) ([]byte, error) {
+    if IsURL(urlOrPath) {
+        return fetch(ctx, urlOrPath, opts)
+    }
+    return os.ReadFile(urlOrPath)
+}
+
+// IsURL reports whether urlOrPath refers to an HTTP(S) resource.
+func IsURL(urlOrPath string) bool {
+    return strings.HasPrefix(urlOrPath, "http://") || strings.HasPrefix(urlOrPath, "https://")
+}
+
+func fetch(ctx context.Context, url string, opts LoadOptions) ([]byte, error) {
+    c := openCache(opts.CacheDir)
+    var cached *cacheEntry
+    if c != nil {
+        cached, _ = c.get(url)
+    }
+
+    if opts.Offline {
+        if cached == nil {
+            return nil, fmt.Errorf("offline mode: %w of %s (run once without --offline to populate the cache)", errNotCached, url)
         }
+        return cached.Data, nil
     }
 
-    var kg model.KnownGood
-    if err := json.Unmarshal(data, &kg); err != nil {
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+    if err != nil {
         return nil, err
     }
+    if cached != nil && cached.ETag != "" {
+        req.Header.Set("If-None-Match", cached.ETag)
+    }
 
-    return &kg, nil
+    resp, err := opts.Client.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode == http.StatusNotModified && cached != nil {
+        return cached.Data, nil
+    }
+    if resp.StatusCode == http.StatusNotFound {
+        return nil, errNotFound
+    }
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("HTTP %s", resp.Status)
+    }
+
+    data, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, err
+    }
+
+    // A cache that cannot be written only costs a download next time.
+    if c != nil {
+        _ = c.put(url, resp.Header.Get("ETag"), data)
+    }
+    return data, nil
 }
@@ -0,0 +1,136 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"context"
+	"fmt"
+
+	"scorex/internal/model"
+)
+
+// Layer is one known_good.json of a merged manifest.
+type Layer struct {
+	Source    string // URL or path it was loaded from
+	KnownGood *model.KnownGood
+}
+
+// Conflict records a module that a later layer pins differently than an
+// earlier one. The later entry wins.
+type Conflict struct {
+	Module     string
+	Source     string // layer of the overridden entry
+	Overridden model.ModuleInfo
+	Overlay    string // layer of the effective entry
+	Effective  model.ModuleInfo
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s from %s overrides %s from %s",
+		c.Module, describe(c.Effective), c.Overlay, describe(c.Overridden), c.Source)
+}
+
+// Merged is a known_good manifest merged from one or more layers.
+type Merged struct {
+	*model.KnownGood // the effective manifest
+
+	Layers    []Layer
+	Conflicts []Conflict
+}
+
+// WarningsFor describes the problems tolerated while loading the layers and the
+// conflicts of the resolved modules for the user. Conflicts of other modules
+// do not affect the project.
+func (m *Merged) WarningsFor(resolved map[string]model.ResolvedModule) []string {
+	var out []string
+	for _, l := range m.Layers {
+		for _, w := range l.KnownGood.Warnings {
+			out = append(out, l.Source+": "+w)
+		}
+	}
+	for _, c := range m.Conflicts {
+		if _, ok := resolved[c.Module]; ok {
+			out = append(out, "known_good overlay: "+c.String())
+		}
+	}
+	return out
+}
+
+// LoadMerged loads urlOrPath and layers the overlays over it in order, so
+// that later manifests override the modules of earlier ones. The expected
+// SHA-256 of opts applies to urlOrPath only; overlays are verified against
+// their detached checksums.
+func LoadMerged(ctx context.Context, urlOrPath string, overlays []string, opts LoadOptions) (*Merged, error) {
+	base, err := LoadWithOptions(ctx, urlOrPath, opts)
+	if err != nil {
+		return nil, err
+	}
+	layers := []Layer{{Source: urlOrPath, KnownGood: base}}
+
+	overlayOpts := opts
+	overlayOpts.ExpectedSHA256 = ""
+	for _, overlay := range overlays {
+		kg, err := LoadWithOptions(ctx, overlay, overlayOpts)
+		if err != nil {
+			return nil, fmt.Errorf("overlay %s: %w", overlay, err)
+		}
+		layers = append(layers, Layer{Source: overlay, KnownGood: kg})
+	}
+
+	kg, conflicts := Merge(layers)
+	return &Merged{KnownGood: kg, Layers: layers, Conflicts: conflicts}, nil
+}
+
+// Merge overlays the modules of layers in order: a later layer overrides the
+// entry of an earlier one. Entries that differ are reported as conflicts.
+// The other fields, such as the timestamp, are taken from the first layer.
+func Merge(layers []Layer) (*model.KnownGood, []Conflict) {
+	if len(layers) == 0 {
+		return &model.KnownGood{Modules: map[string]model.ModuleInfo{}}, nil
+	}
+
+	merged := *layers[0].KnownGood
+	merged.Modules = make(map[string]model.ModuleInfo, len(layers[0].KnownGood.Modules))
+	from := make(map[string]string, len(merged.Modules))
+
+	var conflicts []Conflict
+	for _, l := range layers {
+		for _, name := range sortedModules(l.KnownGood) {
+			mi := l.KnownGood.Modules[name]
+			if prev, ok := merged.Modules[name]; ok && !samePin(prev, mi) {
+				conflicts = append(conflicts, Conflict{
+					Module:     name,
+					Source:     from[name],
+					Overridden: prev,
+					Overlay:    l.Source,
+					Effective:  mi,
+				})
+			}
+			merged.Modules[name] = mi
+			from[name] = l.Source
+		}
+	}
+	return &merged, conflicts
+}
+
+func samePin(a, b model.ModuleInfo) bool {
+	return a.Version == b.Version && a.Hash == b.Hash && a.Repo == b.Repo && a.Branch == b.Branch
+}
+
+func describe(mi model.ModuleInfo) string {
+	hash := mi.Hash
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	return fmt.Sprintf("%s (%s, %s)", mi.Version, hash, mi.Repo)
+}
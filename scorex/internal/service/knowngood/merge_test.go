@@ -0,0 +1,126 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scorex/internal/model"
+)
+
+func TestMergePrecedence(t *testing.T) {
+	base := model.ModuleInfo{Version: "1.0.0", Hash: "aaa", Repo: "https://example.com/base.git"}
+	fork := model.ModuleInfo{Version: "1.0.1", Hash: "bbb", Repo: "https://example.com/fork.git"}
+	patched := model.ModuleInfo{Version: "1.0.2", Hash: "ccc", Repo: "https://example.com/fork.git"}
+	extra := model.ModuleInfo{Version: "0.1.0", Hash: "ddd", Repo: "https://example.com/extra.git"}
+	other := model.ModuleInfo{Version: "2.0.0", Hash: "eee", Repo: "https://example.com/other.git"}
+
+	layers := []Layer{
+		{Source: "base.json", KnownGood: &model.KnownGood{
+			Timestamp: "2026-10-01T00:00:00Z",
+			Modules:   map[string]model.ModuleInfo{"score_base": base, "score_other": other},
+		}},
+		{Source: "forks.json", KnownGood: &model.KnownGood{
+			Timestamp: "2026-10-10T00:00:00Z",
+			Modules:   map[string]model.ModuleInfo{"score_base": fork, "score_other": other},
+		}},
+		{Source: "patched.json", KnownGood: &model.KnownGood{
+			Modules: map[string]model.ModuleInfo{"score_base": patched, "score_extra": extra},
+		}},
+	}
+	kg, conflicts := Merge(layers)
+
+	want := map[string]model.ModuleInfo{"score_base": patched, "score_other": other, "score_extra": extra}
+	if len(kg.Modules) != len(want) {
+		t.Errorf("merged modules = %v, want %v", kg.Modules, want)
+	}
+	for name, mi := range want {
+		if kg.Modules[name] != mi {
+			t.Errorf("merged %s = %+v, want %+v", name, kg.Modules[name], mi)
+		}
+	}
+	if kg.Timestamp != "2026-10-01T00:00:00Z" {
+		t.Errorf("merged timestamp = %q, want the one of the first layer", kg.Timestamp)
+	}
+
+	// score_other is pinned the same in both layers and is no conflict.
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %v, want two for score_base", conflicts)
+	}
+	if c := conflicts[0]; c.Source != "base.json" || c.Overlay != "forks.json" || c.Overridden != base || c.Effective != fork {
+		t.Errorf("first conflict = %+v, want forks.json over base.json", c)
+	}
+	if c := conflicts[1]; c.Source != "forks.json" || c.Overlay != "patched.json" || c.Overridden != fork || c.Effective != patched {
+		t.Errorf("second conflict = %+v, want patched.json over forks.json", c)
+	}
+	if layers[0].KnownGood.Modules["score_base"] != base {
+		t.Error("Merge modified the first layer")
+	}
+}
+
+func TestMergedWarningsFor(t *testing.T) {
+	m := &Merged{
+		Layers: []Layer{{Source: "new.json", KnownGood: &model.KnownGood{Warnings: []string{`unknown field "extra"`}}}},
+		Conflicts: []Conflict{
+			{Module: "score_used", Source: "a.json", Overlay: "b.json"},
+			{Module: "score_unused", Source: "a.json", Overlay: "b.json"},
+		},
+	}
+	warnings := m.WarningsFor(map[string]model.ResolvedModule{"score_used": {}})
+	if len(warnings) != 2 {
+		t.Fatalf("WarningsFor() = %q, want the layer warning and the score_used conflict", warnings)
+	}
+	if warnings[0] != `new.json: unknown field "extra"` {
+		t.Errorf("first warning = %q", warnings[0])
+	}
+	if !strings.HasPrefix(warnings[1], "known_good overlay: score_used:") {
+		t.Errorf("second warning = %q", warnings[1])
+	}
+}
+
+func TestLoadMerged(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	const module = `{"version": "%s", "hash": "%s", "repo": "https://example.com/base.git"}`
+	hashA := strings.Repeat("a", 40)
+	hashB := strings.Repeat("b", 40)
+	base := write("base.json", `{"modules": {"score_base": `+fmt.Sprintf(module, "1.0.0", hashA)+`}}`)
+	overlay := write("overlay.json", `{"modules": {"score_base": `+fmt.Sprintf(module, "1.0.1", hashB)+`}}`)
+
+	m, err := LoadMerged(context.Background(), base, []string{overlay}, LoadOptions{CacheDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Modules["score_base"].Hash; got != hashB {
+		t.Errorf("score_base hash = %s, want the overlay's %s", got, hashB)
+	}
+	if len(m.Layers) != 2 || len(m.Conflicts) != 1 {
+		t.Errorf("got %d layers and %d conflicts, want 2 and 1", len(m.Layers), len(m.Conflicts))
+	}
+
+	missing := filepath.Join(dir, "missing.json")
+	if _, err := LoadMerged(context.Background(), base, []string{missing}, LoadOptions{CacheDir: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "overlay "+missing) {
+		t.Errorf("LoadMerged() with a missing overlay = %v, want an error naming it", err)
+	}
+}
@@ -0,0 +1,211 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"scorex/internal/model"
+)
+
+// What to do with a manifest older than Policy.MaxAge.
+const (
+	StaleWarn = "warn"
+	StaleFail = "fail"
+)
+
+// timestampLayouts are the timestamp formats accepted in known_good.json.
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05", // UTC
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Policy restricts which manifests a project may be resolved against.
+type Policy struct {
+	MaxAge       time.Duration // 0 disables the age check
+	OnStale      string        // StaleWarn (default) or StaleFail
+	RequireSuite string        // empty disables the suite check
+}
+
+// IsZero reports whether p checks nothing.
+func (p Policy) IsZero() bool {
+	return p.MaxAge == 0 && p.RequireSuite == ""
+}
+
+// Over returns p with the unset fields taken from base.
+func (p Policy) Over(base Policy) Policy {
+	if p.MaxAge == 0 {
+		p.MaxAge = base.MaxAge
+	}
+	if p.OnStale == "" {
+		p.OnStale = base.OnStale
+	}
+	if p.RequireSuite == "" {
+		p.RequireSuite = base.RequireSuite
+	}
+	return p
+}
+
+// String describes p for the user, e.g. "max age 14d (fail), suite full".
+func (p Policy) String() string {
+	var parts []string
+	if p.MaxAge > 0 {
+		onStale := p.OnStale
+		if onStale == "" {
+			onStale = StaleWarn
+		}
+		parts = append(parts, fmt.Sprintf("max age %s (%s)", FormatMaxAge(p.MaxAge), onStale))
+	}
+	if p.RequireSuite != "" {
+		parts = append(parts, "suite "+p.RequireSuite)
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Check applies p to kg at time now. A manifest from another suite is an
+// error. A manifest older than MaxAge, or without a readable timestamp, is an
+// error with StaleFail and a warning otherwise.
+func (p Policy) Check(kg *model.KnownGood, now time.Time) ([]string, error) {
+	if p.RequireSuite != "" && kg.Suite != p.RequireSuite {
+		suite := kg.Suite
+		if suite == "" {
+			suite = "no suite"
+		} else {
+			suite = "suite " + strconv.Quote(suite)
+		}
+		return nil, fmt.Errorf("known_good.json was produced by %s, but suite %q is required", suite, p.RequireSuite)
+	}
+	if p.MaxAge == 0 {
+		return nil, nil
+	}
+
+	var problem string
+	if ts, err := ManifestTime(kg); err != nil {
+		problem = fmt.Sprintf("cannot check the age of known_good.json: %v", err)
+	} else if age := now.Sub(ts); age > p.MaxAge {
+		problem = fmt.Sprintf("known_good.json is %s old (from %s), older than the allowed %s",
+			FormatAge(age), kg.Timestamp, FormatMaxAge(p.MaxAge))
+	}
+	if problem == "" {
+		return nil, nil
+	}
+	if p.OnStale == StaleFail {
+		return nil, fmt.Errorf("%s", problem)
+	}
+	return []string{problem}, nil
+}
+
+// ManifestTime parses the timestamp of kg.
+func ManifestTime(kg *model.KnownGood) (time.Time, error) {
+	if kg.Timestamp == "" {
+		return time.Time{}, fmt.Errorf("no timestamp")
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, kg.Timestamp); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported timestamp %q", kg.Timestamp)
+}
+
+// ParseMaxAge parses ages such as "14d", "2w" or any time.ParseDuration
+// value such as "36h".
+func ParseMaxAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v <= 0 {
+				return 0, fmt.Errorf("invalid age %q (use e.g. 14d, 2w or 36h)", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 14d, 2w or 36h)", s)
+	}
+	return d, nil
+}
+
+// FormatAge formats d for display, in whole days if it is at least a day,
+// e.g. "14d". Use FormatMaxAge for values that are parsed again.
+func FormatAge(d time.Duration) string {
+	day := 24 * time.Hour
+	if d >= day {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.Round(time.Minute).String()
+}
+
+// FormatMaxAge formats a maximum age exactly, so that ParseMaxAge returns d
+// again: in days if d is a whole number of days, e.g. "14d", and as
+// time.Duration otherwise, e.g. "36h0m0s".
+func FormatMaxAge(d time.Duration) string {
+	day := 24 * time.Hour
+	if d >= day && d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
+
+// ParseStalePolicy validates the action for manifests older than MaxAge.
+func ParseStalePolicy(s string) (string, error) {
+	switch s {
+	case "", StaleWarn:
+		return StaleWarn, nil
+	case StaleFail:
+		return StaleFail, nil
+	default:
+		return "", fmt.Errorf("unknown action %q (use warn or fail)", s)
+	}
+}
+
+// Locked returns p as recorded in scorex.lock, or nil if p checks nothing.
+func (p Policy) Locked() *model.LockedPolicy {
+	if p.IsZero() {
+		return nil
+	}
+	lp := &model.LockedPolicy{RequireSuite: p.RequireSuite}
+	if p.MaxAge > 0 {
+		lp.MaxAge = FormatMaxAge(p.MaxAge)
+		lp.OnStale = p.OnStale
+		if lp.OnStale == "" {
+			lp.OnStale = StaleWarn
+		}
+	}
+	return lp
+}
+
+// PolicyFromLock returns the policy recorded in scorex.lock.
+func PolicyFromLock(lp *model.LockedPolicy) (Policy, error) {
+	if lp == nil {
+		return Policy{}, nil
+	}
+	maxAge, err := ParseMaxAge(lp.MaxAge)
+	if err != nil {
+		return Policy{}, err
+	}
+	return Policy{MaxAge: maxAge, OnStale: lp.OnStale, RequireSuite: lp.RequireSuite}, nil
+}
@@ -0,0 +1,55 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMaxAgeRoundTrip(t *testing.T) {
+	tests := []struct {
+		flag   string
+		locked string
+	}{
+		{"14d", "14d"},
+		{"2w", "14d"},
+		{"36h", "36h0m0s"},
+		{"90m", "1h30m0s"},
+		{"48h", "2d"},
+	}
+	for _, tt := range tests {
+		d, err := ParseMaxAge(tt.flag)
+		if err != nil {
+			t.Fatalf("ParseMaxAge(%q): %v", tt.flag, err)
+		}
+		lp := Policy{MaxAge: d}.Locked()
+		if lp.MaxAge != tt.locked {
+			t.Errorf("%s: locked max_age = %q, want %q", tt.flag, lp.MaxAge, tt.locked)
+		}
+		p, err := PolicyFromLock(lp)
+		if err != nil {
+			t.Fatalf("%s: PolicyFromLock: %v", tt.flag, err)
+		}
+		if p.MaxAge != d {
+			t.Errorf("%s: max age after round trip = %s, want %s", tt.flag, p.MaxAge, d)
+		}
+	}
+}
+
+func TestPolicyString(t *testing.T) {
+	p := Policy{MaxAge: 36 * time.Hour, OnStale: StaleFail, RequireSuite: "full"}
+	if got, want := p.String(), "max age 36h0m0s (fail), suite full"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
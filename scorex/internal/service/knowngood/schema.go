@@ -0,0 +1,249 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"scorex/internal/model"
+)
+
+// SchemaVersion is the newest known_good.json layout scorex understands.
+// Manifests without a schema_version have layout 1.
+const SchemaVersion = 1
+
+var (
+	// Bazel module names, see https://bazel.build/rules/lib/globals/module#module
+	moduleNamePattern = regexp.MustCompile(`^[a-z]([a-z0-9._-]*[a-z0-9])?$`)
+	commitPattern     = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+	// scp-like git remotes such as git@github.com:eclipse-score/baselibs.git
+	scpRemotePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/\s].*$`)
+)
+
+// fields of layout 1; pin is scorex's own and not part of known_good.json
+var (
+	manifestFields = []string{"schema_version", "timestamp", "modules", "manifest_sha256", "suite", "duration_s"}
+	moduleFields   = []string{"version", "hash", "repo", "branch"}
+)
+
+// SchemaError lists everything wrong with a manifest.
+type SchemaError struct {
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	if len(e.Problems) == 1 {
+		return "invalid known_good.json: " + e.Problems[0]
+	}
+	return "invalid known_good.json:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Parse decodes and validates a known_good.json. It must list at least one
+// module, every module needs a valid name, version, commit hash and
+// repository URL, and unknown fields are rejected. Manifests with a newer schema_version than SchemaVersion are read
+// leniently: their unknown fields are ignored and reported in
+// KnownGood.Warnings instead.
+func Parse(data []byte) (*model.KnownGood, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			return nil, &SchemaError{Problems: []string{"expected a JSON object"}}
+		}
+		return nil, &SchemaError{Problems: []string{err.Error()}}
+	}
+
+	var kg model.KnownGood
+	var problems []string
+	if v, ok := raw["schema_version"]; ok {
+		if err := json.Unmarshal(v, &kg.SchemaVersion); err != nil || kg.SchemaVersion < 1 {
+			return nil, &SchemaError{Problems: []string{fmt.Sprintf("schema_version must be a positive integer, got %s", v)}}
+		}
+	}
+	// Newer layouts may add fields; they are read as far as scorex knows them.
+	reportUnknown := func(fields []string) {
+		if kg.SchemaVersion <= SchemaVersion {
+			problems = append(problems, fields...)
+			return
+		}
+		for _, f := range fields {
+			kg.Warnings = append(kg.Warnings, fmt.Sprintf(
+				"%s (ignored; schema_version %d is newer than %d, which this scorex understands)",
+				f, kg.SchemaVersion, SchemaVersion,
+			))
+		}
+	}
+
+	reportUnknown(unknownFields(raw, manifestFields, ""))
+	for _, f := range []struct {
+		name string
+		dst  any
+	}{
+		{"timestamp", &kg.Timestamp},
+		{"manifest_sha256", &kg.ManifestSHA256},
+		{"suite", &kg.Suite},
+		{"duration_s", &kg.DurationS},
+	} {
+		if v, ok := raw[f.name]; ok {
+			if err := decodeField(v, f.dst); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", f.name, err))
+			}
+		}
+	}
+
+	var modules map[string]map[string]json.RawMessage
+	if v, ok := raw["modules"]; !ok {
+		problems = append(problems, "missing modules")
+	} else if err := json.Unmarshal(v, &modules); err != nil || modules == nil {
+		problems = append(problems, "modules must map module names to objects")
+	} else if len(modules) == 0 {
+		problems = append(problems, "modules is empty")
+	}
+
+	kg.Modules = make(map[string]model.ModuleInfo, len(modules))
+	for _, name := range sortedKeys(modules) {
+		fields := modules[name]
+		prefix := "module " + name + ": "
+		reportUnknown(unknownFields(fields, moduleFields, prefix))
+
+		var mi model.ModuleInfo
+		invalid := make(map[string]bool)
+		for _, f := range []struct {
+			name string
+			dst  *string
+		}{
+			{"version", &mi.Version},
+			{"hash", &mi.Hash},
+			{"repo", &mi.Repo},
+			{"branch", &mi.Branch},
+		} {
+			if v, ok := fields[f.name]; ok {
+				if err := decodeField(v, f.dst); err != nil {
+					problems = append(problems, fmt.Sprintf("%s%s: %v", prefix, f.name, err))
+					invalid[f.name] = true
+				}
+			}
+		}
+		for _, p := range validateModule(name, mi, invalid) {
+			problems = append(problems, prefix+p)
+		}
+		kg.Modules[name] = mi
+	}
+
+	if len(problems) > 0 {
+		return nil, &SchemaError{Problems: problems}
+	}
+	return &kg, nil
+}
+
+// validateModule checks the entry of a module; fields that could not be
+// decoded are already reported and skipped.
+func validateModule(name string, mi model.ModuleInfo, invalid map[string]bool) []string {
+	var problems []string
+	if !moduleNamePattern.MatchString(name) {
+		problems = append(problems, "invalid module name (use lowercase letters, digits, '.', '_' and '-')")
+	}
+	switch {
+	case invalid["version"]:
+	case mi.Version == "":
+		problems = append(problems, "missing version")
+	case strings.ContainsAny(mi.Version, " \t\n\""):
+		problems = append(problems, fmt.Sprintf("invalid version %q", mi.Version))
+	}
+	switch {
+	case invalid["hash"]:
+	case mi.Hash == "":
+		problems = append(problems, "missing hash")
+	case !commitPattern.MatchString(mi.Hash):
+		problems = append(problems, fmt.Sprintf("hash %q is not a full hex commit id", mi.Hash))
+	}
+	switch {
+	case invalid["repo"]:
+	case mi.Repo == "":
+		problems = append(problems, "missing repo")
+	default:
+		if err := checkRemote(mi.Repo); err != nil {
+			problems = append(problems, fmt.Sprintf("repo %q: %v", mi.Repo, err))
+		}
+	}
+	if strings.ContainsAny(mi.Branch, " \t\n") {
+		problems = append(problems, fmt.Sprintf("invalid branch %q", mi.Branch))
+	}
+	return problems
+}
+
+// checkRemote accepts the git remotes git_override can clone: URLs with a
+// host, scp-like user@host:path remotes and absolute local paths.
+func checkRemote(remote string) error {
+	if strings.ContainsAny(remote, " \t\n") {
+		return fmt.Errorf("contains whitespace")
+	}
+	if scpRemotePattern.MatchString(remote) || filepath.IsAbs(remote) {
+		return nil
+	}
+	u, err := url.Parse(remote)
+	if err != nil {
+		return fmt.Errorf("malformed URL")
+	}
+	switch u.Scheme {
+	case "https", "http", "ssh", "git":
+		if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+			return fmt.Errorf("URL needs a host and a repository path")
+		}
+	case "file":
+		if u.Path == "" {
+			return fmt.Errorf("URL needs a path")
+		}
+	case "":
+		return fmt.Errorf("not a URL (missing scheme, e.g. https://)")
+	default:
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	return nil
+}
+
+func unknownFields[V any](fields map[string]V, known []string, prefix string) []string {
+	var out []string
+	for _, name := range sortedKeys(fields) {
+		if !slices.Contains(known, name) {
+			out = append(out, fmt.Sprintf("%sunknown field %q", prefix, name))
+		}
+	}
+	return out
+}
+
+// decodeField decodes a single field, with a short message for wrong types.
+func decodeField(data json.RawMessage, dst any) error {
+	if err := json.Unmarshal(data, dst); err != nil {
+		if te, ok := err.(*json.UnmarshalTypeError); ok {
+			return fmt.Errorf("expected %s, got %s", te.Type, te.Value)
+		}
+		return err
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package knowngood
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseModules(t *testing.T) {
+	const valid = `{"modules": {"score_baselibs": {
+		"version": "0.1.0",
+		"hash": "0123456789abcdef0123456789abcdef01234567",
+		"repo": "https://github.com/eclipse-score/baselibs.git"
+	}}}`
+	kg, err := Parse([]byte(valid))
+	if err != nil {
+		t.Fatalf("Parse(valid) failed: %v", err)
+	}
+	if len(kg.Modules) != 1 {
+		t.Errorf("Parse(valid) has %d modules, want 1", len(kg.Modules))
+	}
+
+	for _, tc := range []struct {
+		name, data, problem string
+	}{
+		{"missing", `{}`, "missing modules"},
+		{"null", `{"modules": null}`, "modules must map module names to objects"},
+		{"empty", `{"modules": {}}`, "modules is empty"},
+		{"array", `{"modules": []}`, "modules must map module names to objects"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.data))
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("Parse(%s) = %v, want a SchemaError", tc.data, err)
+			}
+			if !slices.Contains(schemaErr.Problems, tc.problem) {
+				t.Errorf("Parse(%s) problems = %q, want %q", tc.data, schemaErr.Problems, tc.problem)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"scorex/internal/model"
+	"scorex/internal/service/knowngood"
+)
+
+// errNotCached is returned in offline mode for lookups that were never made
+// online.
+var errNotCached = errors.New("not cached (run once without --offline to populate the cache)")
+
+// Cache keeps the results of network lookups made while resolving modules,
+// so offline runs can resolve the same modules again: the modules found by a
+// fallback resolver and the MODULE.bazel files of pinned commits. A nil
+// *Cache caches nothing.
+type Cache struct {
+	dir string
+}
+
+type cachedLookup struct {
+	Resolver  string           `json:"resolver"`
+	Config    string           `json:"config,omitempty"` // see resolverConfig
+	Name      string           `json:"name"`
+	Module    model.ModuleInfo `json:"module"`
+	FetchedAt time.Time        `json:"fetched_at"`
+}
+
+// OpenCache returns the module cache below dir, or below
+// knowngood.DefaultCacheDir if dir is empty. It returns nil if no cache
+// directory can be determined.
+func OpenCache(dir string) *Cache {
+	if dir == "" {
+		d, err := knowngood.DefaultCacheDir()
+		if err != nil {
+			return nil
+		}
+		dir = d
+	}
+	return &Cache{dir: filepath.Join(dir, "modules")}
+}
+
+// configuredResolver is implemented by resolvers whose answers depend on
+// their configuration, such as the GitHub owner or the registry URL.
+type configuredResolver interface {
+	config() string
+}
+
+// resolverConfig describes where r looks modules up, so lookups made with
+// another owner, branch or URL are not mixed up.
+func resolverConfig(r Resolver) string {
+	if c, ok := r.(configuredResolver); ok {
+		return c.config()
+	}
+	return ""
+}
+
+// lookup returns what resolver, configured as it is now, found for name when
+// it was last asked online.
+func (c *Cache) lookup(resolver Resolver, name string) (model.ModuleInfo, bool) {
+	if c == nil {
+		return model.ModuleInfo{}, false
+	}
+	kind, config := resolver.Name(), resolverConfig(resolver)
+	data, err := os.ReadFile(c.path("lookup", kind, config, name) + ".json")
+	if err != nil {
+		return model.ModuleInfo{}, false
+	}
+	var l cachedLookup
+	if err := json.Unmarshal(data, &l); err != nil || l.Resolver != kind || l.Config != config || l.Name != name {
+		return model.ModuleInfo{}, false
+	}
+	return l.Module, true
+}
+
+// storeLookup records what resolver found for name. A cache that cannot be
+// written only costs the lookup in offline mode.
+func (c *Cache) storeLookup(resolver Resolver, name string, mi model.ModuleInfo) {
+	if c == nil {
+		return
+	}
+	kind, config := resolver.Name(), resolverConfig(resolver)
+	data, err := json.MarshalIndent(cachedLookup{
+		Resolver:  kind,
+		Config:    config,
+		Name:      name,
+		Module:    mi,
+		FetchedAt: time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return
+	}
+	c.write(c.path("lookup", kind, config, name)+".json", data)
+}
+
+// moduleFile returns the cached MODULE.bazel of mi's commit; commits do not
+// change, so a cached copy never goes stale. An empty string means the
+// module has none.
+func (c *Cache) moduleFile(mi model.ModuleInfo) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	data, err := os.ReadFile(c.path("module_file", mi.Repo, mi.Hash))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func (c *Cache) storeModuleFile(mi model.ModuleInfo, content string) {
+	if c == nil {
+		return
+	}
+	c.write(c.path("module_file", mi.Repo, mi.Hash), []byte(content))
+}
+
+// reader wraps read so that the MODULE.bazel files it returns are cached.
+// In offline mode only cached files are returned.
+func (c *Cache) reader(read ModuleFileReader, offline bool) ModuleFileReader {
+	return func(ctx context.Context, mi model.ModuleInfo) (string, error) {
+		if content, ok := c.moduleFile(mi); ok {
+			return content, nil
+		}
+		if offline {
+			return "", errNotCached
+		}
+		content, err := read(ctx, mi)
+		if err == nil {
+			c.storeModuleFile(mi, content)
+		}
+		return content, err
+	}
+}
+
+// path returns the file of an entry of kind, keyed by parts.
+func (c *Cache) path(kind string, parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%s\x00", p)
+	}
+	return filepath.Join(c.dir, kind, hex.EncodeToString(h.Sum(nil)))
+}
+
+func (c *Cache) write(path string, data []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	_ = knowngood.WriteFileAtomic(path, data)
+}
@@ -0,0 +1,147 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"scorex/internal/model"
+)
+
+type stubResolver struct {
+	modules map[string]model.ModuleInfo
+	calls   int
+}
+
+func (r *stubResolver) Name() string { return "stub" }
+
+func (r *stubResolver) Resolve(_ context.Context, name string) (model.ModuleInfo, error) {
+	r.calls++
+	mi, ok := r.modules[name]
+	if !ok {
+		return model.ModuleInfo{}, errors.New("not found")
+	}
+	return mi, nil
+}
+
+func TestOfflineFallbackFromCache(t *testing.T) {
+	cache := OpenCache(t.TempDir())
+	fallback := &stubResolver{modules: map[string]model.ModuleInfo{
+		"score_extra": {Version: "0.2.0", Hash: "abc", Repo: "https://example.com/extra.git"},
+	}}
+	opts := Options{Fallback: fallback, Cache: cache, NoDependencies: true, Confirmed: []string{"score_extra"}}
+
+	if _, err := ResolveAll(context.Background(), []string{"score_extra"}, nil, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	opts.Offline = true
+	resolved, err := ResolveAll(context.Background(), []string{"score_extra"}, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fallback.calls != 1 {
+		t.Errorf("fallback called %d times, want once", fallback.calls)
+	}
+	if rm := resolved["score_extra"]; rm.Hash != "abc" || rm.Source != "stub" {
+		t.Errorf("offline resolution = %+v, want the cached lookup", rm)
+	}
+
+	if _, err := ResolveAll(context.Background(), []string{"score_other"}, nil, opts); err == nil {
+		t.Error("expected an error for a module that was never looked up")
+	}
+}
+
+func TestOfflineDependenciesFromCache(t *testing.T) {
+	knownGood := map[string]model.ModuleInfo{
+		"score_app":  {Version: "1.0.0", Hash: "aaa", Repo: "https://example.com/app.git"},
+		"score_base": {Version: "1.0.0", Hash: "bbb", Repo: "https://example.com/base.git"},
+	}
+	reads := 0
+	read := func(_ context.Context, mi model.ModuleInfo) (string, error) {
+		reads++
+		if mi.Hash == "aaa" {
+			return `bazel_dep(name = "score_base", version = "1.0.0")`, nil
+		}
+		return "", nil
+	}
+	opts := Options{Cache: OpenCache(t.TempDir()), ReadModuleFile: read}
+
+	online, err := ResolveAll(context.Background(), []string{"score_app"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := online["score_base"]; !ok {
+		t.Fatalf("dependency not added online: %v", online)
+	}
+	readsOnline := reads
+
+	opts.Offline = true
+	offline, err := ResolveAll(context.Background(), []string{"score_app"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reads != readsOnline {
+		t.Errorf("offline run read %d MODULE.bazel files, want none", reads-readsOnline)
+	}
+	if rm, ok := offline["score_base"]; !ok || len(rm.RequiredBy) == 0 {
+		t.Errorf("dependency not added offline from the cache: %v", offline)
+	}
+	if w := Warnings(offline); len(w) > 0 {
+		t.Errorf("unexpected warnings: %v", w)
+	}
+}
+
+func TestOfflineDependenciesNotCached(t *testing.T) {
+	knownGood := map[string]model.ModuleInfo{
+		"score_app": {Version: "1.0.0", Hash: "aaa", Repo: "https://example.com/app.git"},
+	}
+	opts := Options{Cache: OpenCache(t.TempDir()), Offline: true}
+
+	resolved, err := ResolveAll(context.Background(), []string{"score_app"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := Warnings(resolved); len(w) != 1 {
+		t.Errorf("warnings = %v, want one about the uncached MODULE.bazel", w)
+	}
+}
+
+func TestOfflineLookupPerResolverConfig(t *testing.T) {
+	cache := OpenCache(t.TempDir())
+	upstream := &GitHubResolver{APIURL: DefaultGitHubAPIURL, Owner: "eclipse-score", Branch: "main"}
+	cache.storeLookup(upstream, "score_extra", model.ModuleInfo{Version: "0.2.0", Hash: "abc", Repo: "https://github.com/eclipse-score/extra.git"})
+
+	opts := Options{Offline: true, Cache: cache, NoDependencies: true, Confirmed: []string{"score_extra"}}
+	for _, fallback := range []Resolver{
+		&GitHubResolver{APIURL: DefaultGitHubAPIURL, Owner: "my-fork", Branch: "main"},
+		&GitHubResolver{APIURL: DefaultGitHubAPIURL, Owner: "eclipse-score", Branch: "release"},
+		&GitHubResolver{APIURL: "https://github.example.com/api/v3", Owner: "eclipse-score", Branch: "main"},
+	} {
+		opts.Fallback = fallback
+		if _, err := ResolveAll(context.Background(), []string{"score_extra"}, nil, opts); err == nil {
+			t.Errorf("offline resolution with %s used a lookup made with %s", resolverConfig(fallback), resolverConfig(upstream))
+		}
+	}
+
+	opts.Fallback = &GitHubResolver{APIURL: DefaultGitHubAPIURL, Owner: "eclipse-score", Branch: "main"}
+	resolved, err := ResolveAll(context.Background(), []string{"score_extra"}, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved["score_extra"].Hash != "abc" {
+		t.Errorf("offline resolution = %+v, want the cached lookup", resolved["score_extra"])
+	}
+}
@@ -0,0 +1,213 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"scorex/internal/model"
+	"scorex/internal/service/httpfetch"
+	"scorex/internal/service/modulefile"
+)
+
+// ModuleFileReader returns the MODULE.bazel content of a module at its pinned
+// commit, or an empty string if the module has none.
+type ModuleFileReader func(ctx context.Context, mi model.ModuleInfo) (string, error)
+
+var githubRepo = regexp.MustCompile(`^https://github\.com/([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// ReadModuleFile is the default ModuleFileReader. Repositories on github.com
+// are read from raw.githubusercontent.com, all others with a shallow git
+// fetch of the pinned commit.
+func ReadModuleFile(ctx context.Context, mi model.ModuleInfo) (string, error) {
+	if m := githubRepo.FindStringSubmatch(mi.Repo); m != nil {
+		return readRawGitHub(ctx, m[1], m[2], mi.Hash)
+	}
+
+	dir, err := os.MkdirTemp("", "scorex-git-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := runGit(ctx, dir, "init", "--quiet", "--bare"); err != nil {
+		return "", err
+	}
+	if _, err := runGit(ctx, dir, "fetch", "--quiet", "--depth", "1", "--filter=blob:none", mi.Repo, mi.Hash); err != nil {
+		return "", err
+	}
+	content, err := runGit(ctx, dir, "show", "FETCH_HEAD:"+modulefile.FileName)
+	if err != nil {
+		// The commit exists, so the file does not.
+		return "", nil
+	}
+	return content, nil
+}
+
+func readRawGitHub(ctx context.Context, owner, repo, hash string) (string, error) {
+	u := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, hash, modulefile.FileName)
+	resp, err := httpfetch.Default().Get(ctx, u, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := io.ReadAll(resp.Body)
+		return string(data), err
+	case http.StatusNotFound:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, u)
+	}
+}
+
+// moduleFileReader returns opts.ReadModuleFile, or ReadModuleFile, reading
+// through opts.Cache. Offline it only reads cached files.
+func (opts Options) moduleFileReader() ModuleFileReader {
+	read := opts.ReadModuleFile
+	if read == nil {
+		read = ReadModuleFile
+	}
+	if opts.Cache == nil && opts.Offline {
+		return func(context.Context, model.ModuleInfo) (string, error) { return "", errNotCached }
+	}
+	if opts.Cache == nil {
+		return read
+	}
+	return opts.Cache.reader(read, opts.Offline)
+}
+
+// scoreDeps returns the S-CORE modules content declares a bazel_dep on.
+func scoreDeps(content string) ([]string, error) {
+	blocks, err := modulefile.Blocks(content)
+	if err != nil {
+		return nil, err
+	}
+	var deps []string
+	for _, b := range blocks {
+		if b.Kind == "bazel_dep" && strings.HasPrefix(b.Module, "score_") {
+			deps = append(deps, b.Module)
+		}
+	}
+	return deps, nil
+}
+
+// addDependencies extends resolved with the S-CORE modules its modules depend
+// on, transitively, taking them from knownGood. Modules added this way record
+// the modules that require them in RequiredBy.
+func addDependencies(ctx context.Context, resolved map[string]model.ResolvedModule, knownGood map[string]model.ModuleInfo, opts Options) {
+	read := opts.moduleFileReader()
+
+	queue := sortedNames(resolved)
+	if opts.Offline && opts.Cache == nil {
+		if len(queue) > 0 {
+			rm := resolved[queue[0]]
+			rm.Warnings = append(rm.Warnings, "dependencies of the selected modules were not checked in offline mode")
+			resolved[queue[0]] = rm
+		}
+		return
+	}
+
+	// The MODULE.bazel files of each round of newly added modules are read
+	// concurrently, then processed in order.
+	for len(queue) > 0 {
+		round := queue
+		queue = nil
+		contents := make([]string, len(round))
+		errs := make([]error, len(round))
+		forEach(ctx, len(round), opts.Jobs, func(i int) {
+			contents[i], errs[i] = read(ctx, resolved[round[i]].ModuleInfo)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		for i, name := range round {
+			rm := resolved[name]
+
+			var deps []string
+			err := errs[i]
+			if err == nil {
+				deps, err = scoreDeps(contents[i])
+			}
+			if err != nil {
+				rm.Warnings = append(rm.Warnings, fmt.Sprintf(
+					"%s: could not read %s at %s, its dependencies were not added: %v",
+					name, modulefile.FileName, ShortHash(rm.Hash), err,
+				))
+				resolved[name] = rm
+				continue
+			}
+
+			for _, dep := range deps {
+				if existing, ok := resolved[dep]; ok {
+					if len(existing.RequiredBy) > 0 && !contains(existing.RequiredBy, name) {
+						existing.RequiredBy = append(existing.RequiredBy, name)
+						resolved[dep] = existing
+					}
+					continue
+				}
+				mi, ok := knownGood[dep]
+				if !ok {
+					rm.Warnings = append(rm.Warnings, fmt.Sprintf(
+						"%s: depends on %s, which is not in known_good; add it explicitly to pin it",
+						name, dep,
+					))
+					resolved[name] = rm
+					continue
+				}
+				resolved[dep] = model.ResolvedModule{ModuleInfo: mi, Source: SourceKnownGood, RequiredBy: []string{name}}
+				queue = append(queue, dep)
+			}
+		}
+	}
+}
+
+// Dependencies describes the modules added because other modules require
+// them, ordered by module name.
+func Dependencies(resolved map[string]model.ResolvedModule) []string {
+	var out []string
+	for _, name := range sortedNames(resolved) {
+		if rm := resolved[name]; len(rm.RequiredBy) > 0 {
+			out = append(out, fmt.Sprintf("%s (required by %s)", name, strings.Join(rm.RequiredBy, ", ")))
+		}
+	}
+	return out
+}
+
+func sortedNames(resolved map[string]model.ResolvedModule) []string {
+	names := make([]string, 0, len(resolved))
+	for name := range resolved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
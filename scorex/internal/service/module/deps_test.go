@@ -0,0 +1,172 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"scorex/internal/model"
+)
+
+// depsGraph serves MODULE.bazel files declaring the given dependencies, keyed
+// by commit, and counts the reads of each.
+type depsGraph struct {
+	mu    sync.Mutex
+	deps  map[string][]string
+	reads map[string]int
+}
+
+func (g *depsGraph) read(_ context.Context, mi model.ModuleInfo) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.reads[mi.Hash]++
+	deps, ok := g.deps[mi.Hash]
+	if !ok {
+		return "", errors.New("fetch failed")
+	}
+	var b strings.Builder
+	b.WriteString(`module(name = "` + mi.Hash + `")` + "\n")
+	for _, dep := range deps {
+		b.WriteString(`bazel_dep(name = "` + dep + `", version = "1.0.0")` + "\n")
+	}
+	b.WriteString(`bazel_dep(name = "rules_cc", version = "0.2.1")` + "\n")
+	return b.String(), nil
+}
+
+// depsKnownGood pins each module to a commit named after it.
+func depsKnownGood(names ...string) map[string]model.ModuleInfo {
+	knownGood := make(map[string]model.ModuleInfo, len(names))
+	for _, name := range names {
+		knownGood[name] = model.ModuleInfo{Version: "1.0.0", Hash: name, Repo: "https://example.com/" + name + ".git"}
+	}
+	return knownGood
+}
+
+func TestDependencyClosure(t *testing.T) {
+	knownGood := depsKnownGood("score_a", "score_b", "score_c", "score_d", "score_unused")
+	g := &depsGraph{
+		deps: map[string][]string{
+			"score_a": {"score_b", "score_d"},
+			"score_b": {"score_c", "score_d"},
+			// A cycle back to a dependency and to the selected module.
+			"score_c": {"score_a", "score_b"},
+			"score_d": {"score_missing"},
+		},
+		reads: map[string]int{},
+	}
+	opts := Options{ReadModuleFile: g.read}
+
+	resolved, err := ResolveAll(context.Background(), []string{"score_a"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := sortedNames(resolved), []string{"score_a", "score_b", "score_c", "score_d"}; !slices.Equal(got, want) {
+		t.Fatalf("resolved %q, want %q", got, want)
+	}
+	for name, n := range g.reads {
+		if n != 1 {
+			t.Errorf("MODULE.bazel of %s read %d times, want once", name, n)
+		}
+	}
+	requiredBy := map[string][]string{
+		"score_a": nil,
+		"score_b": {"score_a", "score_c"},
+		"score_c": {"score_b"},
+		"score_d": {"score_a", "score_b"},
+	}
+	for name, want := range requiredBy {
+		rm := resolved[name]
+		if !slices.Equal(rm.RequiredBy, want) {
+			t.Errorf("%s required by %q, want %q", name, rm.RequiredBy, want)
+		}
+		if rm.Source != SourceKnownGood || rm.Hash != name {
+			t.Errorf("%s = %+v, want its known_good entry", name, rm)
+		}
+	}
+
+	want := []string{
+		"score_b (required by score_a, score_c)",
+		"score_c (required by score_b)",
+		"score_d (required by score_a, score_b)",
+	}
+	if got := Dependencies(resolved); !slices.Equal(got, want) {
+		t.Errorf("Dependencies() = %q, want %q", got, want)
+	}
+
+	w := Warnings(resolved)
+	if len(w) != 1 || !strings.Contains(w[0], "depends on score_missing, which is not in known_good") {
+		t.Errorf("warnings = %q, want one about score_missing", w)
+	}
+}
+
+func TestDependencySelectedExplicitly(t *testing.T) {
+	knownGood := depsKnownGood("score_a", "score_b")
+	g := &depsGraph{
+		deps:  map[string][]string{"score_a": {"score_b"}, "score_b": {"score_a"}},
+		reads: map[string]int{},
+	}
+	opts := Options{ReadModuleFile: g.read}
+
+	resolved, err := ResolveAll(context.Background(), []string{"score_a", "score_b"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Dependencies(resolved); len(got) != 0 {
+		t.Errorf("Dependencies() = %q, want none for selected modules", got)
+	}
+}
+
+func TestDependencyReadFails(t *testing.T) {
+	knownGood := depsKnownGood("score_a", "score_b", "score_c")
+	g := &depsGraph{
+		// The MODULE.bazel of score_b cannot be read.
+		deps:  map[string][]string{"score_a": {"score_b"}, "score_c": nil},
+		reads: map[string]int{},
+	}
+	opts := Options{ReadModuleFile: g.read}
+
+	resolved, err := ResolveAll(context.Background(), []string{"score_a"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sortedNames(resolved), []string{"score_a", "score_b"}; !slices.Equal(got, want) {
+		t.Errorf("resolved %q, want %q", got, want)
+	}
+	w := Warnings(resolved)
+	if len(w) != 1 || !strings.Contains(w[0], "score_b: could not read MODULE.bazel") {
+		t.Errorf("warnings = %q, want one about the MODULE.bazel of score_b", w)
+	}
+}
+
+func TestNoDependencies(t *testing.T) {
+	knownGood := depsKnownGood("score_a", "score_b")
+	g := &depsGraph{
+		deps:  map[string][]string{"score_a": {"score_b"}},
+		reads: map[string]int{},
+	}
+	opts := Options{ReadModuleFile: g.read, NoDependencies: true}
+
+	resolved, err := ResolveAll(context.Background(), []string{"score_a"}, knownGood, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved) != 1 || len(g.reads) != 0 {
+		t.Errorf("resolved %q after %d reads, want only score_a and no reads", sortedNames(resolved), len(g.reads))
+	}
+}
@@ -0,0 +1,149 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"scorex/internal/model"
+	"scorex/internal/service/httpfetch"
+)
+
+// Resolver resolves modules that are not listed in known_good.
+type Resolver interface {
+	// Name identifies the resolver; it is recorded as resolution source in
+	// scorex.lock.
+	Name() string
+	// Resolve returns the module with an empty Version if it has none. If
+	// looking the version up failed, it returns the module together with a
+	// *VersionError.
+	Resolve(ctx context.Context, name string) (model.ModuleInfo, error)
+}
+
+// VersionError reports that the commit of a module was found but looking up
+// its version failed. ResolveModule turns it into a warning.
+type VersionError struct {
+	Module string
+	Err    error
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("looking up the version of %s: %v", e.Module, e.Err)
+}
+
+func (e *VersionError) Unwrap() error { return e.Err }
+
+// Resolver kinds selectable with --resolver.
+const (
+	ResolverKnownGood = "known-good"
+	ResolverGitHub    = "github"
+	ResolverGit       = "git"
+	ResolverRegistry  = "registry"
+)
+
+// Defaults of ResolverConfig.
+const (
+	DefaultGitHubAPIURL = "https://api.github.com"
+	DefaultOwner        = "eclipse-score"
+	DefaultBranch       = "main"
+	DefaultRegistryURL  = "https://raw.githubusercontent.com/eclipse-score/bazel_registry/main"
+)
+
+// ResolverConfig selects and configures a fallback resolver.
+type ResolverConfig struct {
+	Kind   string            // one of the Resolver* kinds, defaults to ResolverGitHub
+	Owner  string            // GitHub organisation or user
+	Host   string            // GitHub API base URL, e.g. https://github.example.com/api/v3
+	Branch string            // branch to take the latest commit from (github, git)
+	URL    string            // remote URL template with {repo} (git) or registry base URL (registry)
+	Token  string            // GitHub token, defaults to $GITHUB_TOKEN
+	Client *httpfetch.Client // defaults to httpfetch.Default()
+}
+
+// ResolverKinds lists all values accepted for ResolverConfig.Kind.
+func ResolverKinds() []string {
+	return []string{ResolverKnownGood, ResolverGitHub, ResolverGit, ResolverRegistry}
+}
+
+// DefaultResolver returns the resolver used when none is configured: the
+// latest commit on main of github.com/eclipse-score/<repo>.
+func DefaultResolver() Resolver {
+	r, _ := NewResolver(ResolverConfig{})
+	return r
+}
+
+// NewResolver creates the fallback resolver described by cfg.
+func NewResolver(cfg ResolverConfig) (Resolver, error) {
+	if cfg.Owner == "" {
+		cfg.Owner = DefaultOwner
+	}
+	if cfg.Branch == "" {
+		cfg.Branch = DefaultBranch
+	}
+
+	switch cfg.Kind {
+	case ResolverKnownGood:
+		return knownGoodOnlyResolver{}, nil
+	case ResolverGitHub, "":
+		host := cfg.Host
+		if host == "" {
+			host = DefaultGitHubAPIURL
+		}
+		token := cfg.Token
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		return &GitHubResolver{
+			APIURL: strings.TrimSuffix(host, "/"),
+			Owner:  cfg.Owner,
+			Branch: cfg.Branch,
+			Token:  token,
+			Client: cfg.Client,
+		}, nil
+	case ResolverGit:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("resolver %q needs a remote URL template, e.g. https://git.example.com/score/{repo}.git", cfg.Kind)
+		}
+		if !strings.Contains(cfg.URL, "{repo}") {
+			return nil, fmt.Errorf("remote URL template %q lacks the {repo} placeholder", cfg.URL)
+		}
+		return &GitResolver{URLTemplate: cfg.URL, Branch: cfg.Branch}, nil
+	case ResolverRegistry:
+		url := cfg.URL
+		if url == "" {
+			url = DefaultRegistryURL
+		}
+		return &RegistryResolver{URL: strings.TrimSuffix(url, "/"), Client: cfg.Client}, nil
+	default:
+		return nil, fmt.Errorf("unknown resolver %q (use %s)", cfg.Kind, strings.Join(ResolverKinds(), ", "))
+	}
+}
+
+// repoName maps a module name to its repository name, e.g. score_baselibs
+// to baselibs.
+func repoName(module string) string {
+	return strings.TrimPrefix(module, "score_")
+}
+
+// knownGoodOnlyResolver never resolves anything, so only modules listed in
+// known_good can be used.
+type knownGoodOnlyResolver struct{}
+
+func (knownGoodOnlyResolver) Name() string { return ResolverKnownGood }
+
+func (knownGoodOnlyResolver) Resolve(ctx context.Context, name string) (model.ModuleInfo, error) {
+	return model.ModuleInfo{}, fmt.Errorf("fallback lookups are disabled (--resolver=%s)", ResolverKnownGood)
+}
@@ -0,0 +1,120 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"scorex/internal/model"
+	"scorex/internal/service/modulefile"
+)
+
+// GitResolver takes the latest commit of a branch from any git remote, e.g. a
+// GitLab mirror. It clones the branch without file contents to read the
+// module's MODULE.bazel and tags.
+type GitResolver struct {
+	URLTemplate string // remote URL with a {repo} placeholder
+	Branch      string
+}
+
+func (r *GitResolver) Name() string { return ResolverGit }
+
+func (r *GitResolver) config() string { return r.URLTemplate + " " + r.Branch }
+
+func (r *GitResolver) Resolve(ctx context.Context, name string) (model.ModuleInfo, error) {
+	remote := strings.ReplaceAll(r.URLTemplate, "{repo}", repoName(name))
+
+	dir, err := os.MkdirTemp("", "scorex-git-")
+	if err != nil {
+		return model.ModuleInfo{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := runGit(ctx, "", "clone", "--quiet", "--bare", "--filter=blob:none", "--single-branch", "--branch", r.Branch, remote, dir); err != nil {
+		return model.ModuleInfo{}, fmt.Errorf("branch %q of %s: %w", r.Branch, remote, err)
+	}
+	sha, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return model.ModuleInfo{}, err
+	}
+
+	return model.ModuleInfo{
+		Version: gitVersion(ctx, dir, sha),
+		Hash:    sha,
+		Repo:    remote,
+		Branch:  r.Branch,
+	}, nil
+}
+
+// gitVersion detects the version at sha in the repository in dir: the
+// version declared in its MODULE.bazel, else the nearest semver tag. It
+// returns an empty string if neither is available.
+func gitVersion(ctx context.Context, dir, sha string) string {
+	if content, err := runGit(ctx, dir, "show", sha+":"+modulefile.FileName); err == nil {
+		if v := declaredVersion(content); v != "" {
+			return v
+		}
+	}
+	for _, pattern := range []string{"v[0-9]*", "[0-9]*"} {
+		tag, err := runGit(ctx, dir, "describe", "--tags", "--abbrev=0", "--match", pattern, sha)
+		if err == nil && tagVersion(tag) != "" {
+			return tagVersion(tag)
+		}
+	}
+	return ""
+}
+
+// runGit runs git in dir and returns its trimmed output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if ctx.Err() != nil {
+		return "", ctx.Err() // git was killed
+	}
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// lsRemote returns the commit ref points to in remote, or an empty string if
+// the ref does not exist. Annotated tags are peeled to their commit.
+func lsRemote(ctx context.Context, remote, ref string) (string, error) {
+	out, err := runGit(ctx, "", "ls-remote", remote, ref, ref+"^{}")
+	if err != nil {
+		return "", err
+	}
+
+	sha := ""
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[1] {
+		case ref + "^{}":
+			return fields[0], nil
+		case ref:
+			sha = fields[0]
+		}
+	}
+	return sha, nil
+}
@@ -0,0 +1,183 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"scorex/internal/model"
+	"scorex/internal/service/httpfetch"
+	"scorex/internal/service/modulefile"
+)
+
+// GitHubResolver takes the latest commit of a branch through the GitHub REST
+// API, on github.com or a GitHub Enterprise server.
+type GitHubResolver struct {
+	APIURL string // e.g. https://api.github.com or https://github.example.com/api/v3
+	Owner  string
+	Branch string
+	Token  string // optional, sent as bearer token
+	Client *httpfetch.Client
+}
+
+// maxTagComparisons limits the compare API calls spent on finding the
+// nearest tag of a commit that is not tagged itself.
+const maxTagComparisons = 5
+
+var errNotFound = errors.New("not found")
+
+func (r *GitHubResolver) Name() string { return ResolverGitHub }
+
+func (r *GitHubResolver) config() string { return r.APIURL + " " + r.Owner + " " + r.Branch }
+
+func (r *GitHubResolver) Resolve(ctx context.Context, name string) (model.ModuleInfo, error) {
+	repo := repoName(name)
+	sha, err := r.latestCommit(ctx, repo)
+	if err != nil {
+		return model.ModuleInfo{}, err
+	}
+	mi := model.ModuleInfo{
+		Hash:   sha,
+		Repo:   fmt.Sprintf("%s/%s/%s.git", r.webURL(), r.Owner, repo),
+		Branch: r.Branch,
+	}
+	// The commit is enough to pin the module; a failed version lookup, e.g.
+	// after hitting the rate limit, only costs the version.
+	mi.Version, err = r.version(ctx, repo, sha)
+	if err != nil {
+		if ctx.Err() != nil {
+			return model.ModuleInfo{}, ctx.Err()
+		}
+		return mi, &VersionError{Module: name, Err: err}
+	}
+	return mi, nil
+}
+
+// webURL derives the clone host from the API URL.
+func (r *GitHubResolver) webURL() string {
+	if r.APIURL == DefaultGitHubAPIURL {
+		return "https://github.com"
+	}
+	return strings.TrimSuffix(r.APIURL, "/api/v3")
+}
+
+func (r *GitHubResolver) latestCommit(ctx context.Context, repo string) (string, error) {
+	var payload struct {
+		SHA string `json:"sha"`
+	}
+	if err := r.getJSON(ctx, fmt.Sprintf("repos/%s/%s/commits/%s", r.Owner, repo, url.PathEscape(r.Branch)), &payload); err != nil {
+		return "", err
+	}
+
+	if payload.SHA == "" {
+		return "", fmt.Errorf("no sha in GitHub response")
+	}
+	return payload.SHA, nil
+}
+
+// version detects the version of repo at sha: the version declared in its
+// MODULE.bazel, else the nearest semver tag. It returns an empty string if
+// neither is available.
+func (r *GitHubResolver) version(ctx context.Context, repo, sha string) (string, error) {
+	content, err := r.get(ctx,
+		fmt.Sprintf("repos/%s/%s/contents/%s?ref=%s", r.Owner, repo, modulefile.FileName, sha),
+		"application/vnd.github.raw+json",
+	)
+	if err != nil && !errors.Is(err, errNotFound) {
+		return "", err
+	}
+	if v := declaredVersion(string(content)); v != "" {
+		return v, nil
+	}
+	return r.nearestTag(ctx, repo, sha)
+}
+
+// nearestTag returns the version of the highest semver tag that points at
+// sha or one of its ancestors.
+func (r *GitHubResolver) nearestTag(ctx context.Context, repo, sha string) (string, error) {
+	var tags []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := r.getJSON(ctx, fmt.Sprintf("repos/%s/%s/tags?per_page=100", r.Owner, repo), &tags); err != nil {
+		if errors.Is(err, errNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t.Commit.SHA == sha && tagVersion(t.Name) != "" {
+			return tagVersion(t.Name), nil
+		}
+		names = append(names, t.Name)
+	}
+
+	for i, tag := range sortTagsDescending(names) {
+		if i == maxTagComparisons {
+			break
+		}
+		var cmp struct {
+			Status string `json:"status"`
+		}
+		path := fmt.Sprintf("repos/%s/%s/compare/%s...%s", r.Owner, repo, url.PathEscape(tag), sha)
+		if err := r.getJSON(ctx, path, &cmp); err != nil {
+			return "", err
+		}
+		if cmp.Status == "ahead" || cmp.Status == "identical" {
+			return tagVersion(tag), nil
+		}
+	}
+	return "", nil
+}
+
+func (r *GitHubResolver) getJSON(ctx context.Context, path string, v any) error {
+	data, err := r.get(ctx, path, "application/vnd.github+json")
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// get requests path below the API URL. A 404 response yields errNotFound.
+func (r *GitHubResolver) get(ctx context.Context, path, accept string) ([]byte, error) {
+	header := http.Header{"Accept": {accept}}
+	if r.Token != "" {
+		header.Set("Authorization", "Bearer "+r.Token)
+	}
+
+	resp, err := r.Client.Get(ctx, r.APIURL+"/"+path, header)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", path, errNotFound)
+	default:
+		return nil, fmt.Errorf("unexpected status %d from GitHub", resp.StatusCode)
+	}
+}
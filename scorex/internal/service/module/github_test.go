@@ -0,0 +1,90 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"scorex/internal/service/httpfetch"
+)
+
+const testSHA = "0123456789abcdef0123456789abcdef01234567"
+
+// newGitHub serves the latest commit of eclipse-score/extra on main and
+// answers every other request with status.
+func newGitHub(t *testing.T, status int) *GitHubResolver {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/repos/eclipse-score/extra/commits/main" {
+			w.Write([]byte(`{"sha": "` + testSHA + `"}`))
+			return
+		}
+		http.Error(w, "failed", status)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := httpfetch.New(httpfetch.Config{Retries: -1, NetrcFile: os.DevNull})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &GitHubResolver{APIURL: srv.URL, Owner: "eclipse-score", Branch: "main", Client: client}
+}
+
+func TestGitHubVersionLookupFails(t *testing.T) {
+	r := newGitHub(t, http.StatusInternalServerError)
+
+	mi, err := r.Resolve(context.Background(), "score_extra")
+	var versionErr *VersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("Resolve() error = %v, want a VersionError", err)
+	}
+	if mi.Hash != testSHA || mi.Version != "" {
+		t.Errorf("Resolve() = %+v, want commit %s without a version", mi, testSHA)
+	}
+
+	rm, err := ResolveModule(context.Background(), "score_extra", nil, Options{Fallback: r, Confirmed: []string{"score_extra"}})
+	if err != nil {
+		t.Fatalf("ResolveModule() failed: %v", err)
+	}
+	if rm.Hash != testSHA || rm.Version != DefaultVersion {
+		t.Errorf("ResolveModule() = %+v, want commit %s with version %s", rm, testSHA, DefaultVersion)
+	}
+	if len(rm.Warnings) != 1 || !strings.Contains(rm.Warnings[0], "could not look up the version") {
+		t.Errorf("warnings = %q, want one about the version lookup", rm.Warnings)
+	}
+}
+
+func TestGitHubWithoutVersion(t *testing.T) {
+	r := newGitHub(t, http.StatusNotFound) // no MODULE.bazel, no tags
+
+	mi, err := r.Resolve(context.Background(), "score_extra")
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if mi.Hash != testSHA || mi.Version != "" {
+		t.Errorf("Resolve() = %+v, want commit %s without a version", mi, testSHA)
+	}
+}
+
+func TestGitHubLatestCommitFails(t *testing.T) {
+	r := newGitHub(t, http.StatusInternalServerError)
+
+	if _, err := r.Resolve(context.Background(), "score_missing"); err == nil {
+		t.Fatal("Resolve() of a module without a commit succeeded")
+	}
+}
@@ -0,0 +1,75 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"scorex/internal/model"
+	"scorex/internal/service/modulefile"
+)
+
+// PinLocal pins a module to a local checkout with local_path_override.
+const PinLocal = "local"
+
+// CheckLocalModule verifies that path, relative to projectDir, holds a
+// MODULE.bazel declaring the module name.
+func CheckLocalModule(projectDir, name, path string) error {
+	dir := path
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectDir, path)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, modulefile.FileName))
+	if err != nil {
+		return fmt.Errorf("%s does not contain a Bazel module: %w", path, err)
+	}
+	m, ok, err := modulefile.ParseModule(string(data))
+	if err != nil {
+		return fmt.Errorf("parsing %s in %s: %w", modulefile.FileName, path, err)
+	}
+	if !ok || m.Name != name {
+		declared := m.Name
+		if !ok {
+			declared = "no module"
+		}
+		return fmt.Errorf("%s in %s declares %s, not %s", modulefile.FileName, path, declared, name)
+	}
+	return nil
+}
+
+// ApplyLocalPaths pins the modules in local, keyed by module name, to their
+// local checkouts. All modules must be part of resolved.
+func ApplyLocalPaths(resolved map[string]model.ResolvedModule, local map[string]string, projectDir string) error {
+	names := make([]string, 0, len(local))
+	for name := range local {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := local[name]
+		rm, ok := resolved[name]
+		if !ok {
+			return fmt.Errorf("module %q is not part of the project", name)
+		}
+		if err := CheckLocalModule(projectDir, name, path); err != nil {
+			return err
+		}
+		rm.Pin = &model.Pin{Mode: PinLocal, Path: filepath.ToSlash(path)}
+		resolved[name] = rm
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"scorex/internal/model"
+)
+
+// Search ranks the modules of known_good and of the catalog (which may be
+// nil) by how well their names and catalog descriptions match term. Exact
+// and substring matches of the name come first, then substring matches of
+// the description, then names containing the letters of term in order, then
+// words within a small edit distance of term, so that typos still match.
+func Search(term string, known map[string]model.ModuleInfo, catalog *model.ModuleCatalog) []string {
+	term = strings.ToLower(strings.TrimSpace(term))
+	if term == "" {
+		return nil
+	}
+
+	candidates := make(map[string]string, len(known))
+	for name := range known {
+		candidates[name] = ""
+	}
+	if catalog != nil {
+		for name, entry := range catalog.Modules {
+			candidates[name] = entry.Description
+		}
+	}
+
+	ranks := make(map[string]int)
+	for name, description := range candidates {
+		if rank, ok := matchRank(term, name, description); ok {
+			ranks[name] = rank
+		}
+	}
+
+	out := make([]string, 0, len(ranks))
+	for name := range ranks {
+		out = append(out, name)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if ranks[out[i]] != ranks[out[j]] {
+			return ranks[out[i]] < ranks[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+func matchRank(term, name, description string) (int, bool) {
+	short := strings.TrimPrefix(strings.ToLower(name), "score_")
+	description = strings.ToLower(description)
+	term = strings.TrimPrefix(term, "score_")
+
+	switch {
+	case short == term:
+		return 0, true
+	case strings.Contains(short, term):
+		return 1, true
+	case strings.Contains(description, term):
+		return 2, true
+	case isSubsequence(term, short):
+		return 3, true
+	}
+
+	maxDistance := maxTypos(term)
+	words := append(splitWords(short), splitWords(description)...)
+	for _, w := range words {
+		if distance(term, w) <= maxDistance {
+			return 4, true
+		}
+	}
+	return 0, false
+}
+
+// KnownNames returns the sorted module names of known_good.
+func KnownNames(knownGood map[string]model.ModuleInfo) []string {
+	names := make([]string, 0, len(knownGood))
+	for name := range knownGood {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// maxSuggestions limits how many names Suggest returns.
+const maxSuggestions = 3
+
+// UnknownModuleError reports a module that is missing from known_good while
+// its name is close to a known one, so that it is most likely a typo.
+type UnknownModuleError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *UnknownModuleError) Error() string {
+	return fmt.Sprintf(
+		"module %q is not in known_good; did you mean %s? To resolve %s anyway, pin it with %s@<ref>",
+		e.Name, strings.Join(e.Suggestions, " or "), e.Name, e.Name,
+	)
+}
+
+// Suggest returns the candidates whose names are closest to name, best
+// first: those within a few typos, then those that contain name as a part,
+// like score_inc_daal for daal. It returns nothing if name is a candidate
+// itself.
+func Suggest(name string, candidates []string) []string {
+	name = NormalizeName(strings.ToLower(strings.TrimSpace(name)))
+	short := strings.TrimPrefix(name, "score_")
+	maxDistance := max(1, maxTypos(short))
+
+	dist := make(map[string]int)
+	for _, c := range candidates {
+		c = NormalizeName(c)
+		if c == name {
+			return nil
+		}
+		cShort := strings.TrimPrefix(c, "score_")
+		if d := distance(short, cShort); d <= maxDistance {
+			dist[c] = d
+		} else if contains(strings.Split(cShort, "_"), short) {
+			dist[c] = maxDistance + 1
+		}
+	}
+
+	out := make([]string, 0, len(dist))
+	for c := range dist {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if dist[out[i]] != dist[out[j]] {
+			return dist[out[i]] < dist[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	if len(out) > maxSuggestions {
+		out = out[:maxSuggestions]
+	}
+	return out
+}
+
+// maxTypos is the edit distance up to which a word still matches term.
+func maxTypos(term string) int {
+	n := len([]rune(term))
+	switch {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func isSubsequence(sub, s string) bool {
+	rs := []rune(s)
+	i := 0
+	for _, r := range sub {
+		for i < len(rs) && rs[i] != r {
+			i++
+		}
+		if i == len(rs) {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,201 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"scorex/internal/model"
+)
+
+// SourceOverride is recorded in scorex.lock for modules pinned to a ref given
+// on the command line instead of their known_good entry.
+const SourceOverride = "override"
+
+// Ref kinds of a module override.
+const (
+	RefCommit = "commit"
+	RefTag    = "tag"
+	RefBranch = "branch"
+)
+
+// refBranchPrefix marks a branch ref, e.g. score_baselibs@branch:feature/x.
+const refBranchPrefix = "branch:"
+
+// Ref is a git ref a module is pinned to instead of its known_good entry.
+type Ref struct {
+	Kind  string // RefCommit, RefTag or RefBranch
+	Value string
+}
+
+var (
+	fullCommit  = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	shortCommit = regexp.MustCompile(`^[0-9a-f]{7,39}$`)
+)
+
+// ParseRef parses the part after @ in a module argument: a full commit hash,
+// branch:<name> or a tag such as v0.3.1.
+func ParseRef(s string) (Ref, error) {
+	switch {
+	case s == "":
+		return Ref{}, fmt.Errorf("empty ref")
+	case strings.HasPrefix(s, refBranchPrefix):
+		branch := strings.TrimPrefix(s, refBranchPrefix)
+		if branch == "" {
+			return Ref{}, fmt.Errorf("empty branch name in %q", s)
+		}
+		return Ref{Kind: RefBranch, Value: branch}, nil
+	case fullCommit.MatchString(s):
+		return Ref{Kind: RefCommit, Value: s}, nil
+	case shortCommit.MatchString(s):
+		return Ref{}, fmt.Errorf("%q looks like an abbreviated commit; use the full 40-character hash", s)
+	default:
+		return Ref{Kind: RefTag, Value: s}, nil
+	}
+}
+
+// String returns the ref in the syntax ParseRef accepts.
+func (r Ref) String() string {
+	if r.Kind == RefBranch {
+		return refBranchPrefix + r.Value
+	}
+	return r.Value
+}
+
+// ParseModuleArg splits a module argument such as score_baselibs@v0.3.1 into
+// the normalized module name and its ref. The ref is nil if arg has none.
+func ParseModuleArg(arg string) (string, *Ref, error) {
+	name, ref, ok := strings.Cut(arg, "@")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("missing module name in %q", arg)
+	}
+	name = NormalizeName(name)
+	if !ok {
+		return name, nil, nil
+	}
+	r, err := ParseRef(strings.TrimSpace(ref))
+	if err != nil {
+		return "", nil, fmt.Errorf("module %s: %w", name, err)
+	}
+	return name, &r, nil
+}
+
+// ParseModuleArgs splits module arguments into module names, in the given
+// order, and the refs given for some of them.
+func ParseModuleArgs(args []string) ([]string, map[string]Ref, error) {
+	names := make([]string, 0, len(args))
+	refs := make(map[string]Ref)
+	for _, arg := range args {
+		name, ref, err := ParseModuleArg(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		names = append(names, name)
+		if ref != nil {
+			refs[name] = *ref
+		}
+	}
+	return names, refs, nil
+}
+
+// ParseRefs parses refs as stored in scorex.json, keyed by module name.
+func ParseRefs(stored map[string]string) (map[string]Ref, error) {
+	refs := make(map[string]Ref, len(stored))
+	for name, s := range stored {
+		r, err := ParseRef(s)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", name, err)
+		}
+		refs[NormalizeName(name)] = r
+	}
+	return refs, nil
+}
+
+// FormatRefs is the inverse of ParseRefs.
+func FormatRefs(refs map[string]Ref) map[string]string {
+	if len(refs) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(refs))
+	for name, r := range refs {
+		out[name] = r.String()
+	}
+	return out
+}
+
+// resolveOverride pins a module to ref. The repository comes from known_good,
+// or from the fallback resolver for modules not listed there.
+func resolveOverride(ctx context.Context, name string, knownGood map[string]model.ModuleInfo, ref Ref, opts Options) (model.ResolvedModule, error) {
+	baseOpts := opts
+	baseOpts.Overrides = nil
+	// An explicit ref confirms a name that looks like a typo.
+	baseOpts.Confirmed = append(slices.Clone(opts.Confirmed), name)
+	base, err := ResolveModule(ctx, name, knownGood, baseOpts)
+	if err != nil {
+		return model.ResolvedModule{}, err
+	}
+
+	mi := base.ModuleInfo
+	mi.Branch = ""
+	switch ref.Kind {
+	case RefCommit:
+		mi.Hash = ref.Value
+	case RefTag, RefBranch:
+		if opts.Offline {
+			return model.ResolvedModule{}, fmt.Errorf("offline mode cannot look up %s %q of %s", ref.Kind, ref.Value, name)
+		}
+		gitRef := "refs/tags/" + ref.Value
+		if ref.Kind == RefBranch {
+			gitRef = "refs/heads/" + ref.Value
+			mi.Branch = ref.Value
+		}
+		sha, err := lsRemote(ctx, mi.Repo, gitRef)
+		if err != nil {
+			return model.ResolvedModule{}, err
+		}
+		if sha == "" {
+			return model.ResolvedModule{}, fmt.Errorf("%s %q not found in %s", ref.Kind, ref.Value, mi.Repo)
+		}
+		mi.Hash = sha
+	}
+
+	rm := model.ResolvedModule{ModuleInfo: mi, Source: SourceOverride, Warnings: base.Warnings}
+	rm.Version = overrideVersion(ctx, mi, ref, opts)
+	if rm.Version == "" {
+		rm.Version = base.Version
+		rm.Warnings = append(rm.Warnings, fmt.Sprintf(
+			"%s: could not detect the version at %s %s; using version %s",
+			name, ref.Kind, ref.Value, base.Version,
+		))
+	}
+	return rm, nil
+}
+
+// overrideVersion detects the version of a module pinned to ref: the version
+// declared in its MODULE.bazel, else the tag itself.
+func overrideVersion(ctx context.Context, mi model.ModuleInfo, ref Ref, opts Options) string {
+	if content, err := opts.moduleFileReader()(ctx, mi); err == nil {
+		if v := declaredVersion(content); v != "" {
+			return v
+		}
+	}
+	if ref.Kind == RefTag {
+		return tagVersion(ref.Value)
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"scorex/internal/model"
+)
+
+// TestOverrideConfirmsTypo follows the hint of UnknownModuleError: a name
+// close to a known one resolves once it is pinned to a ref.
+func TestOverrideConfirmsTypo(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	knownGood := map[string]model.ModuleInfo{
+		"score_baselibs": {Version: "1.0.0", Hash: "aaa", Repo: "https://example.com/baselibs.git"},
+	}
+	fallback := &stubResolver{modules: map[string]model.ModuleInfo{
+		"score_baselib": {Version: "0.1.0", Hash: "bbb", Repo: "https://example.com/baselib.git"},
+	}}
+	opts := Options{Fallback: fallback, NoDependencies: true}
+
+	_, err := ResolveAll(context.Background(), []string{"score_baselib"}, knownGood, opts)
+	var unknown *UnknownModuleError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("ResolveAll() without a ref = %v, want an UnknownModuleError", err)
+	}
+
+	opts.Overrides = map[string]Ref{"score_baselib": {Kind: RefCommit, Value: sha}}
+	opts.ReadModuleFile = func(context.Context, model.ModuleInfo) (string, error) {
+		return `module(name = "score_baselib", version = "0.2.0")`, nil
+	}
+	resolved, err := ResolveAll(context.Background(), []string{"score_baselib"}, knownGood, opts)
+	if err != nil {
+		t.Fatalf("ResolveAll() with a ref failed: %v", err)
+	}
+	rm := resolved["score_baselib"]
+	if rm.Hash != sha || rm.Repo != "https://example.com/baselib.git" || rm.Version != "0.2.0" {
+		t.Errorf("ResolveAll() = %+v, want the fallback repository pinned to %s", rm, sha)
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	tests := []struct {
+		in   string
+		want Ref
+	}{
+		{sha, Ref{Kind: RefCommit, Value: sha}},
+		{"v0.3.1", Ref{Kind: RefTag, Value: "v0.3.1"}},
+		{"1.0.0", Ref{Kind: RefTag, Value: "1.0.0"}},
+		{"branch:feature/x", Ref{Kind: RefBranch, Value: "feature/x"}},
+		// Too long for a commit, so a tag.
+		{sha + "0", Ref{Kind: RefTag, Value: sha + "0"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRef(tt.in)
+		if err != nil {
+			t.Errorf("ParseRef(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRef(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if got.String() != tt.in {
+			t.Errorf("ParseRef(%q).String() = %q", tt.in, got.String())
+		}
+	}
+
+	for _, in := range []string{"", "branch:", "0123abc", sha[:39]} {
+		if r, err := ParseRef(in); err == nil {
+			t.Errorf("ParseRef(%q) = %+v, want an error", in, r)
+		}
+	}
+}
+
+func TestParseModuleArgs(t *testing.T) {
+	names, refs, err := ParseModuleArgs([]string{"baselibs@v0.3.1", "score_feo", " lifecycle @ branch:main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"score_baselibs", "score_feo", "score_lifecycle"}; !slices.Equal(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	want := map[string]Ref{
+		"score_baselibs":  {Kind: RefTag, Value: "v0.3.1"},
+		"score_lifecycle": {Kind: RefBranch, Value: "main"},
+	}
+	if !maps.Equal(refs, want) {
+		t.Errorf("refs = %+v, want %+v", refs, want)
+	}
+
+	// scorex.json stores the refs in the syntax of the command line.
+	parsed, err := ParseRefs(FormatRefs(refs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maps.Equal(parsed, refs) {
+		t.Errorf("ParseRefs(FormatRefs()) = %+v, want %+v", parsed, refs)
+	}
+
+	for _, arg := range []string{"@v1.0.0", "baselibs@", "baselibs@branch:"} {
+		if _, _, err := ParseModuleArg(arg); err == nil {
+			t.Errorf("ParseModuleArg(%q) succeeded, want an error", arg)
+		}
+	}
+}
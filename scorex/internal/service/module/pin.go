@@ -0,0 +1,138 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"scorex/internal/model"
+	"scorex/internal/service/httpfetch"
+)
+
+// Pin modes selectable with --pin-mode.
+const (
+	PinGit      = "git"      // bazel_dep plus git_override
+	PinRegistry = "registry" // plain bazel_dep, resolved by the Bazel registry
+	PinArchive  = "archive"  // bazel_dep plus archive_override with integrity
+)
+
+// PinOptions controls how resolved modules are pinned in MODULE.bazel.
+type PinOptions struct {
+	Mode        string // defaults to PinGit
+	RegistryURL string // registry checked in PinRegistry mode, defaults to DefaultRegistryURL
+	Offline     bool
+	Client      *httpfetch.Client // defaults to httpfetch.Default()
+}
+
+// ParsePinMode validates a --pin-mode value; empty means PinGit.
+func ParsePinMode(s string) (string, error) {
+	switch s {
+	case "":
+		return PinGit, nil
+	case PinGit, PinRegistry, PinArchive:
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid pin mode %q (use %s, %s or %s)", s, PinGit, PinRegistry, PinArchive)
+	}
+}
+
+// ApplyPinMode sets how each resolved module is pinned. Modules that cannot
+// be pinned as requested keep their git_override, with a warning. It only
+// fails if ctx is cancelled.
+func ApplyPinMode(ctx context.Context, resolved map[string]model.ResolvedModule, opts PinOptions) error {
+	mode := opts.Mode
+	if mode == "" || mode == PinGit {
+		for name, rm := range resolved {
+			rm.Pin = nil
+			resolved[name] = rm
+		}
+		return nil
+	}
+
+	registry := &RegistryResolver{URL: strings.TrimSuffix(opts.RegistryURL, "/"), Client: opts.Client}
+	if registry.URL == "" {
+		registry.URL = DefaultRegistryURL
+	}
+
+	for _, name := range sortedNames(resolved) {
+		rm := resolved[name]
+
+		var pin *model.Pin
+		var err error
+		switch {
+		case opts.Offline:
+			err = fmt.Errorf("offline mode")
+		case mode == PinRegistry:
+			pin, err = registryPin(ctx, registry, name, rm.Version)
+		case mode == PinArchive:
+			pin, err = archivePin(ctx, opts.Client, rm.ModuleInfo)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			rm.Warnings = append(rm.Warnings, fmt.Sprintf("%s: cannot pin in %s mode, keeping git_override: %v", name, mode, err))
+		}
+		rm.Pin = pin
+		resolved[name] = rm
+	}
+	return nil
+}
+
+func registryPin(ctx context.Context, registry *RegistryResolver, name, version string) (*model.Pin, error) {
+	ok, err := registry.HasVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("version %s is not in %s", version, registry.URL)
+	}
+	return &model.Pin{Mode: PinRegistry}, nil
+}
+
+// archivePin downloads the source archive of the pinned commit to compute its
+// integrity. Only github.com repositories are supported.
+func archivePin(ctx context.Context, client *httpfetch.Client, mi model.ModuleInfo) (*model.Pin, error) {
+	m := githubRepo.FindStringSubmatch(mi.Repo)
+	if m == nil {
+		return nil, fmt.Errorf("archive URLs are only known for github.com repositories, not %s", mi.Repo)
+	}
+	url := fmt.Sprintf("https://github.com/%s/%s/archive/%s.tar.gz", m[1], m[2], mi.Hash)
+
+	resp, err := client.WithTimeout(2*time.Minute).Get(ctx, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, url)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", url, err)
+	}
+	return &model.Pin{
+		Mode:        PinArchive,
+		URL:         url,
+		Integrity:   "sha256-" + base64.StdEncoding.EncodeToString(h.Sum(nil)),
+		StripPrefix: m[2] + "-" + mi.Hash,
+	}, nil
+}
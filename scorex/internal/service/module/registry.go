@@ -0,0 +1,207 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"scorex/internal/model"
+	"scorex/internal/service/httpfetch"
+)
+
+// RegistryResolver takes the newest version a Bazel registry (such as the
+// S-CORE bazel_registry) publishes for a module.
+type RegistryResolver struct {
+	URL    string // base URL of the registry, containing modules/<name>/...
+	Client *httpfetch.Client
+}
+
+func (r *RegistryResolver) Name() string { return ResolverRegistry }
+
+func (r *RegistryResolver) config() string { return r.URL }
+
+type registryMetadata struct {
+	Versions   []string `json:"versions"`
+	Repository []string `json:"repository"`
+}
+
+type registrySource struct {
+	URL string `json:"url"`
+}
+
+var archiveCommit = regexp.MustCompile(`/archive/([0-9a-f]{40})\.(?:tar\.gz|zip)$`)
+
+func (r *RegistryResolver) Resolve(ctx context.Context, name string) (model.ModuleInfo, error) {
+	var meta registryMetadata
+	if err := r.getJSON(ctx, fmt.Sprintf("modules/%s/metadata.json", name), &meta); err != nil {
+		return model.ModuleInfo{}, err
+	}
+	version := latestVersion(meta.Versions)
+	if version == "" {
+		return model.ModuleInfo{}, fmt.Errorf("registry lists no versions of %s", name)
+	}
+	repo := repositoryURL(meta.Repository)
+	if repo == "" {
+		return model.ModuleInfo{}, fmt.Errorf("registry metadata of %s has no repository", name)
+	}
+
+	var src registrySource
+	if err := r.getJSON(ctx, fmt.Sprintf("modules/%s/%s/source.json", name, version), &src); err != nil {
+		return model.ModuleInfo{}, err
+	}
+
+	hash := ""
+	if m := archiveCommit.FindStringSubmatch(src.URL); m != nil {
+		hash = m[1]
+	} else {
+		for _, tag := range []string{"v" + version, version} {
+			sha, err := lsRemote(ctx, repo, "refs/tags/"+tag)
+			if err != nil {
+				return model.ModuleInfo{}, err
+			}
+			if sha != "" {
+				hash = sha
+				break
+			}
+		}
+	}
+	if hash == "" {
+		return model.ModuleInfo{}, fmt.Errorf("no commit found for %s %s in %s", name, version, repo)
+	}
+
+	return model.ModuleInfo{
+		Version: version,
+		Hash:    hash,
+		Repo:    repo,
+	}, nil
+}
+
+// HasVersion reports whether the registry publishes the given version of a
+// module.
+func (r *RegistryResolver) HasVersion(ctx context.Context, name, version string) (bool, error) {
+	var meta registryMetadata
+	if err := r.getJSON(ctx, fmt.Sprintf("modules/%s/metadata.json", name), &meta); err != nil {
+		if errors.Is(err, errNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, v := range meta.Versions {
+		if v == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *RegistryResolver) getJSON(ctx context.Context, path string, v any) error {
+	u := r.URL + "/" + path
+	resp, err := r.Client.Get(ctx, u, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNotFound:
+		return fmt.Errorf("%s: %w", u, errNotFound)
+	default:
+		return fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, u)
+	}
+}
+
+// repositoryURL turns the first registry repository entry, e.g.
+// github:eclipse-score/baselibs, into a git remote URL.
+func repositoryURL(repos []string) string {
+	for _, repo := range repos {
+		if rest, ok := strings.CutPrefix(repo, "github:"); ok {
+			return "https://github.com/" + rest + ".git"
+		}
+		if strings.Contains(repo, "://") {
+			return repo
+		}
+	}
+	return ""
+}
+
+// latestVersion returns the highest of the given versions, comparing their
+// dot-separated numeric parts.
+func latestVersion(versions []string) string {
+	latest := ""
+	for _, v := range versions {
+		if latest == "" || compareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// compareVersions compares two versions such as 1.2.0 or v0.3.1-rc1 by their
+// numeric components; pre-release suffixes sort before the release.
+func compareVersions(a, b string) int {
+	ac, apre := splitVersion(a)
+	bc, bpre := splitVersion(b)
+	for i := 0; i < len(ac) || i < len(bc); i++ {
+		var x, y int
+		if i < len(ac) {
+			x = ac[i]
+		}
+		if i < len(bc) {
+			y = bc[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case apre == bpre:
+		return 0
+	case apre == "":
+		return 1
+	case bpre == "":
+		return -1
+	case apre < bpre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func splitVersion(v string) ([]int, string) {
+	v = strings.TrimPrefix(v, "v")
+	pre := ""
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v, pre = v[:i], v[i+1:]
+	}
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			n = 0
+		}
+		parts = append(parts, n)
+	}
+	return parts, pre
+}
@@ -1,97 +1,279 @@
 package module
 
 import (
-    "encoding/json"
+    "context"
+    "errors"
     "fmt"
-    "net/http"
     "strings"
-    "time"
+    "sync"
 
     "scorex/internal/model"
 )
 
+// SourceKnownGood is recorded in scorex.lock for modules taken from
+// known_good. Modules resolved by a fallback record its Resolver.Name.
+const SourceKnownGood = "known_good"
+
+// DefaultJobs is the number of modules resolved concurrently by default.
+const DefaultJobs = 4
+
+// Options controls how modules missing from known_good are resolved.
+type Options struct {
+    Offline  bool     // use Cache instead of asking the fallback resolver
+    Fallback Resolver // defaults to DefaultResolver()
+
+    // Cache records fallback lookups and MODULE.bazel files, so offline runs
+    // can resolve the same modules again. Nil disables it; offline runs then
+    // fail for modules missing from known_good.
+    Cache *Cache
+
+    // NoDependencies disables adding the S-CORE modules the selected modules
+    // depend on.
+    NoDependencies bool
+    ReadModuleFile ModuleFileReader // defaults to ReadModuleFile
+
+    // Overrides pins modules to a ref instead of their known_good entry.
+    Overrides map[string]Ref
+
+    // Modules missing from known_good whose names are close to a known_good
+    // module or to one of SuggestFrom fail with an UnknownModuleError instead
+    // of being passed to the fallback, unless they are listed in Confirmed.
+    SuggestFrom []string
+    Confirmed   []string
+
+    // Jobs bounds how many modules are resolved, and how many MODULE.bazel
+    // files are read, at the same time; defaults to DefaultJobs. Fallback and
+    // ReadModuleFile must be safe for concurrent use.
+    Jobs int
+}
+
 // ResolveModuleWithFallback tries to resolve a module from knownGood.
-// If not present, it falls back to GitHub "latest release" for the given repo name.
+// If not present, it falls back to DefaultResolver.
 func ResolveModuleWithFallback(
+    ctx context.Context,
     name string,
     knownGood map[string]model.ModuleInfo,
 ) (model.ModuleInfo, error) {
+    rm, err := ResolveModule(ctx, name, knownGood, Options{})
+    return rm.ModuleInfo, err
+}
+
+// ResolveModule works like ResolveModuleWithFallback, but also reports where
+// the module was resolved from.
+func ResolveModule(
+    ctx context.Context,
+    name string,
+    knownGood map[string]model.ModuleInfo,
+    opts Options,
+) (model.ResolvedModule, error) {
+    if ref, ok := opts.Overrides[name]; ok {
+        return resolveOverride(ctx, name, knownGood, ref, opts)
+    }
+
     if mi, ok := knownGood[name]; ok {
-        return mi, nil
+        return model.ResolvedModule{ModuleInfo: mi, Source: SourceKnownGood}, nil
     }
 
-    repoName := strings.TrimPrefix(name, "score_")
+    if !contains(opts.Confirmed, name) {
+        candidates := append(KnownNames(knownGood), opts.SuggestFrom...)
+        if suggestions := Suggest(name, candidates); len(suggestions) > 0 {
+            return model.ResolvedModule{}, &UnknownModuleError{Name: name, Suggestions: suggestions}
+        }
+    }
 
-    // Fallback: neuester Commit auf main von GitHub
-    latestCommit, err := fetchLatestGithubMainCommit("eclipse-score", repoName)
-    if err != nil {
-        return model.ModuleInfo{}, fmt.Errorf(
-            "module %q not in known_good and GitHub lookup failed: %w",
-            name, err,
-        )
+    fallback := opts.Fallback
+    if fallback == nil {
+        fallback = DefaultResolver()
     }
 
-    mi := model.ModuleInfo{
-        Version: "0.1.0", //TODO get correct version number
-        Hash:    latestCommit,
-        Repo:    fmt.Sprintf("https://github.com/eclipse-score/%s.git", repoName),
-        Branch:  "main",
+    var mi model.ModuleInfo
+    var versionErr *VersionError
+    if opts.Offline {
+        cached, ok := opts.Cache.lookup(fallback, name)
+        if !ok {
+            return model.ResolvedModule{}, fmt.Errorf(
+                "module %q not in known_good and offline mode does not allow a %s lookup (%v)",
+                name, fallback.Name(), errNotCached,
+            )
+        }
+        mi = cached
+    } else {
+        var err error
+        mi, err = fallback.Resolve(ctx, name)
+        if errors.As(err, &versionErr) {
+            err = nil
+        }
+        if err != nil {
+            return model.ResolvedModule{}, fmt.Errorf(
+                "module %q not in known_good and %s lookup failed: %w",
+                name, fallback.Name(), err,
+            )
+        }
+        opts.Cache.storeLookup(fallback, name, mi)
+    }
+
+    rm := model.ResolvedModule{ModuleInfo: mi, Source: fallback.Name()}
+    switch {
+    case rm.Version != "":
+    case versionErr != nil:
+        rm.Version = DefaultVersion
+        rm.Warnings = append(rm.Warnings, fmt.Sprintf(
+            "%s: could not look up the version at %s (%v); using version %s",
+            name, ShortHash(mi.Hash), versionErr.Err, DefaultVersion,
+        ))
+    default:
+        rm.Version = DefaultVersion
+        rm.Warnings = append(rm.Warnings, fmt.Sprintf(
+            "%s: found neither a version in MODULE.bazel nor a semver tag at %s; using version %s",
+            name, ShortHash(mi.Hash), DefaultVersion,
+        ))
+    }
+    return rm, nil
+}
+
+// Warnings collects the warnings of all resolved modules, ordered by module
+// name.
+func Warnings(resolved map[string]model.ResolvedModule) []string {
+    var out []string
+    for _, name := range sortedNames(resolved) {
+        out = append(out, resolved[name].Warnings...)
     }
+    return out
+}
 
-    return mi, nil
+// ShortHash abbreviates a commit hash for messages.
+func ShortHash(hash string) string {
+    if len(hash) > 12 {
+        return hash[:12]
+    }
+    return hash
 }
 
 // ResolveModules resolves a list of module names against the known-good set,
 // automatically prefixing names with "score_" when missing and falling back
-// to GitHub if a module is not present in known_good.
+// to GitHub if a module is not present in known_good. Unlike ResolveAll it
+// does not add the S-CORE modules the selected modules depend on.
 func ResolveModules(
+    ctx context.Context,
     modules []string,
     knownGood map[string]model.ModuleInfo,
 ) (map[string]model.ModuleInfo, error) {
-    selected := make(map[string]model.ModuleInfo, len(modules))
+    resolved, err := ResolveAll(ctx, modules, knownGood, Options{NoDependencies: true})
+    if err != nil {
+        return nil, err
+    }
+    return ModuleInfos(resolved), nil
+}
 
+// ResolveAll works like ResolveModules, but also reports where each module
+// was resolved from. Up to opts.Jobs modules are resolved concurrently; the
+// first failure cancels the others.
+func ResolveAll(
+    ctx context.Context,
+    modules []string,
+    knownGood map[string]model.ModuleInfo,
+    opts Options,
+) (map[string]model.ResolvedModule, error) {
+    var names []string
     for _, name := range modules {
-        moduleName := name
-        if !strings.HasPrefix(moduleName, "score_") {
-            moduleName = "score_" + moduleName
+        if moduleName := NormalizeName(name); !contains(names, moduleName) {
+            names = append(names, moduleName)
         }
+    }
 
-        mi, err := ResolveModuleWithFallback(moduleName, knownGood)
-        if err != nil {
-            return nil, fmt.Errorf("resolving module %q failed: %w", moduleName, err)
+    resolveCtx, cancel := context.WithCancel(ctx)
+    defer cancel()
+    results := make([]model.ResolvedModule, len(names))
+    errs := make([]error, len(names))
+    forEach(resolveCtx, len(names), opts.Jobs, func(i int) {
+        results[i], errs[i] = ResolveModule(resolveCtx, names[i], knownGood, opts)
+        if errs[i] != nil {
+            cancel()
+        }
+    })
+    if err := ctx.Err(); err != nil {
+        return nil, err
+    }
+    if i, err := firstError(errs); err != nil {
+        var unknown *UnknownModuleError
+        if errors.As(err, &unknown) {
+            return nil, err // already names the module
         }
-        selected[moduleName] = mi
+        return nil, fmt.Errorf("resolving module %q failed: %w", names[i], err)
     }
 
+    selected := make(map[string]model.ResolvedModule, len(names))
+    for i, name := range names {
+        selected[name] = results[i]
+    }
+
+    if !opts.NoDependencies {
+        addDependencies(ctx, selected, knownGood, opts)
+        if err := ctx.Err(); err != nil {
+            return nil, err
+        }
+    }
     return selected, nil
 }
 
-func fetchLatestGithubMainCommit(owner, repo string) (string, error) {
-    url := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/main", owner, repo)
-
-    client := http.Client{
-        Timeout: 5 * time.Second,
+// forEach calls fn for 0..n-1 with at most jobs calls running at a time,
+// defaulting to DefaultJobs. Once ctx is done no further calls are started.
+func forEach(ctx context.Context, n, jobs int, fn func(i int)) {
+    if jobs <= 0 {
+        jobs = DefaultJobs
     }
-
-    resp, err := client.Get(url)
-    if err != nil {
-        return "", err
+    sem := make(chan struct{}, jobs)
+    var wg sync.WaitGroup
+    defer wg.Wait()
+    for i := 0; i < n; i++ {
+        select {
+        case <-ctx.Done():
+            return
+        case sem <- struct{}{}:
+        }
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            defer func() { <-sem }()
+            fn(i)
+        }()
     }
-    defer resp.Body.Close()
+}
 
-    if resp.StatusCode != http.StatusOK {
-        return "", fmt.Errorf("unexpected status %d from GitHub", resp.StatusCode)
+// firstError returns the first error in errs that is not a consequence of
+// cancelling the others, if any.
+func firstError(errs []error) (int, error) {
+    first := -1
+    for i, err := range errs {
+        if err == nil {
+            continue
+        }
+        if !errors.Is(err, context.Canceled) {
+            return i, err
+        }
+        if first < 0 {
+            first = i
+        }
     }
-
-    var payload struct {
-        SHA string `json:"sha"`
+    if first < 0 {
+        return 0, nil
     }
-    if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-        return "", err
+    return first, errs[first]
+}
+
+// ModuleInfos strips the resolution details from resolved modules.
+func ModuleInfos(resolved map[string]model.ResolvedModule) map[string]model.ModuleInfo {
+    out := make(map[string]model.ModuleInfo, len(resolved))
+    for name, rm := range resolved {
+        out[name] = rm.ModuleInfo
     }
+    return out
+}
 
-    if payload.SHA == "" {
-        return "", fmt.Errorf("no sha in GitHub response")
+// NormalizeName prefixes a module name with "score_" when missing.
+func NormalizeName(name string) string {
+    if strings.HasPrefix(name, "score_") {
+        return name
     }
-    return payload.SHA, nil
+    return "score_" + name
 }
@@ -0,0 +1,57 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "sync/atomic"
+    "testing"
+
+    "scorex/internal/model"
+    "scorex/internal/service/httpfetch"
+)
+
+// TestResolveModulesWithoutDependencies makes sure the legacy wrapper does
+// not fetch MODULE.bazel files of the resolved modules.
+func TestResolveModulesWithoutDependencies(t *testing.T) {
+    var requests atomic.Int32
+    proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        requests.Add(1)
+        http.Error(w, "no network in tests", http.StatusBadGateway)
+    }))
+    defer proxy.Close()
+    client, err := httpfetch.New(httpfetch.Config{Proxy: proxy.URL, Retries: -1, NetrcFile: os.DevNull})
+    if err != nil {
+        t.Fatal(err)
+    }
+    previous := httpfetch.Default()
+    httpfetch.SetDefault(client)
+    defer httpfetch.SetDefault(previous)
+
+    knownGood := map[string]model.ModuleInfo{
+        "score_app": {Version: "1.0.0", Hash: "aaa", Repo: "https://github.com/eclipse-score/app.git"},
+    }
+    resolved, err := ResolveModules(context.Background(), []string{"app"}, knownGood)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(resolved) != 1 || resolved["score_app"].Hash != "aaa" {
+        t.Errorf("ResolveModules() = %v, want only score_app", resolved)
+    }
+    if n := requests.Load(); n != 0 {
+        t.Errorf("ResolveModules made %d HTTP requests, want none", n)
+    }
+}
@@ -0,0 +1,61 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package module
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"scorex/internal/service/modulefile"
+)
+
+// DefaultVersion is used for bazel_dep when a fallback resolver cannot detect
+// the version of a module. The git_override pins the commit regardless.
+const DefaultVersion = "0.1.0"
+
+var semverTag = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?$`)
+
+// declaredVersion returns the version declared by module() in the given
+// MODULE.bazel content, or an empty string.
+func declaredVersion(content string) string {
+	m, ok, err := modulefile.ParseModule(content)
+	if err != nil || !ok {
+		return ""
+	}
+	return m.Version
+}
+
+// tagVersion turns a semver tag such as v0.3.1 into a version, or returns an
+// empty string if tag is no semver tag.
+func tagVersion(tag string) string {
+	if !semverTag.MatchString(tag) {
+		return ""
+	}
+	return strings.TrimPrefix(tag, "v")
+}
+
+// sortTagsDescending orders semver tags from the highest to the lowest
+// version and drops all other tags.
+func sortTagsDescending(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if tagVersion(t) != "" {
+			out = append(out, t)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return compareVersions(out[i], out[j]) > 0
+	})
+	return out
+}
@@ -0,0 +1,47 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package modulefile
+
+import "regexp"
+
+// Module is the module() declaration at the top of a MODULE.bazel file.
+type Module struct {
+	Name    string
+	Version string // empty if the module declares no version
+}
+
+var versionAttr = regexp.MustCompile(`\bversion\s*=\s*"([^"]*)"`)
+
+// ParseModule returns the module() declaration in content. It reports false
+// if content declares no module.
+func ParseModule(content string) (Module, bool, error) {
+	calls, err := scanCalls(content)
+	if err != nil {
+		return Module{}, false, err
+	}
+	for _, c := range calls {
+		if c.kind != "module" {
+			continue
+		}
+		text := content[c.start:c.end]
+		var m Module
+		if sub := nameAttr.FindStringSubmatch(text); sub != nil {
+			m.Name = sub[1]
+		}
+		if sub := versionAttr.FindStringSubmatch(text); sub != nil {
+			m.Version = sub[1]
+		}
+		return m, true, nil
+	}
+	return Module{}, false, nil
+}
@@ -0,0 +1,262 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package modulefile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"scorex/internal/model"
+)
+
+// FileName is the name of the Bazel module file in a generated project.
+const FileName = "MODULE.bazel"
+
+// Block is a top-level call in a MODULE.bazel file that belongs to a module,
+// e.g. bazel_dep(name = ...) or git_override(module_name = ...).
+type Block struct {
+	Kind   string // called function, e.g. "bazel_dep" or "git_override"
+	Module string // value of name (bazel_dep) or module_name (overrides)
+	Text   string // source text of the call
+	start  int
+	end    int
+}
+
+var (
+	nameAttr       = regexp.MustCompile(`\bname\s*=\s*"([^"]*)"`)
+	moduleNameAttr = regexp.MustCompile(`\bmodule_name\s*=\s*"([^"]*)"`)
+)
+
+// Blocks returns all bazel_dep and *_override calls found in content.
+func Blocks(content string) ([]Block, error) {
+	calls, err := scanCalls(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Block
+	for _, c := range calls {
+		text := content[c.start:c.end]
+		var m []string
+		switch {
+		case c.kind == "bazel_dep":
+			m = nameAttr.FindStringSubmatch(text)
+		case strings.HasSuffix(c.kind, "_override"):
+			m = moduleNameAttr.FindStringSubmatch(text)
+		default:
+			continue
+		}
+		if m == nil {
+			continue
+		}
+		out = append(out, Block{Kind: c.kind, Module: m[1], Text: text, start: c.start, end: c.end})
+	}
+	return out, nil
+}
+
+// Patch replaces the blocks of all managed modules in content with freshly
+// rendered blocks for modules. Everything else in the file is left untouched.
+//
+// Modules listed in managed but missing from modules are removed. The new
+// blocks are inserted where the first managed block was found. If there was
+// none, they go after the last module block, or to the end of the file.
+func Patch(content string, managed []string, modules map[string]model.ModuleInfo) (string, error) {
+	blocks, err := Blocks(content)
+	if err != nil {
+		return "", err
+	}
+
+	drop := make(map[string]struct{}, len(managed)+len(modules))
+	for _, n := range managed {
+		drop[n] = struct{}{}
+	}
+	for n := range modules {
+		drop[n] = struct{}{}
+	}
+
+	// Generated files inherit the line endings of the templates; keep them.
+	rendered := Render(modules)
+	nl := "\n"
+	if strings.Contains(content, "\r\n") {
+		nl = "\r\n"
+		rendered = strings.ReplaceAll(rendered, "\n", nl)
+	}
+
+	var b strings.Builder
+	pos := 0
+	inserted := false
+	for _, blk := range blocks {
+		if _, ok := drop[blk.Module]; !ok {
+			continue
+		}
+		start, end := expandBlock(content, blk)
+		if start < pos {
+			start = pos
+		}
+		b.WriteString(content[pos:start])
+		if !inserted && rendered != "" {
+			b.WriteString(rendered)
+		} else if b.Len() > 0 && end < len(content) && !endsWithBlankLine(b.String()) && endsWithBlankLine(content[start:end]) {
+			// Keep the removed block's blank line between its neighbours.
+			b.WriteString(nl)
+		}
+		inserted = true
+		pos = end
+	}
+	b.WriteString(content[pos:])
+
+	if inserted || len(modules) == 0 {
+		return b.String(), nil
+	}
+
+	// Nothing to replace: add the new blocks after the last module block.
+	if len(blocks) > 0 {
+		_, end := expandBlock(content, blocks[len(blocks)-1])
+		return content[:end] + rendered + content[end:], nil
+	}
+	return strings.TrimRight(content, "\r\n") + nl + nl + rendered, nil
+}
+
+// endsWithBlankLine reports whether s ends with an empty line.
+func endsWithBlankLine(s string) bool {
+	s = strings.TrimSuffix(s, "\n")
+	s = strings.TrimSuffix(s, "\r")
+	return strings.HasSuffix(s, "\n")
+}
+
+// expandBlock widens a block to whole lines, including a "# <module>" comment
+// directly above it and any blank lines that follow.
+func expandBlock(content string, blk Block) (int, int) {
+	start := strings.LastIndexByte(content[:blk.start], '\n') + 1
+	if strings.TrimSpace(content[start:blk.start]) != "" {
+		start = blk.start
+	}
+	if start > 0 {
+		prevStart := strings.LastIndexByte(content[:start-1], '\n') + 1
+		if strings.TrimSpace(content[prevStart:start]) == "# "+blk.Module {
+			start = prevStart
+		}
+	}
+
+	end := blk.end
+	if nl := strings.IndexByte(content[end:], '\n'); nl >= 0 {
+		rest := strings.TrimSpace(content[end : end+nl])
+		if rest == "" || strings.HasPrefix(rest, "#") {
+			end += nl + 1
+		}
+	} else {
+		end = len(content)
+	}
+	for end < len(content) {
+		nl := strings.IndexByte(content[end:], '\n')
+		if nl < 0 || strings.TrimSpace(content[end:end+nl]) != "" {
+			break
+		}
+		end += nl + 1
+	}
+	return start, end
+}
+
+type call struct {
+	kind  string
+	start int
+	end   int
+}
+
+// scanCalls finds all top-level function calls in a Starlark file. It knows
+// enough about comments and string literals to not be fooled by parentheses
+// inside them.
+func scanCalls(content string) ([]call, error) {
+	var calls []call
+	depth := 0
+	current := call{start: -1}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '#':
+			nl := strings.IndexByte(content[i:], '\n')
+			if nl < 0 {
+				i = len(content)
+			} else {
+				i += nl
+			}
+		case c == '"' || c == '\'':
+			end, err := skipString(content, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end - 1
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q at offset %d", c, i)
+			}
+			if depth == 0 && current.start >= 0 {
+				current.end = i + 1
+				calls = append(calls, current)
+				current = call{start: -1}
+			}
+		case depth == 0 && isIdentStart(c):
+			j := i
+			for j < len(content) && isIdentPart(content[j]) {
+				j++
+			}
+			k := j
+			for k < len(content) && (content[k] == ' ' || content[k] == '\t') {
+				k++
+			}
+			if k < len(content) && content[k] == '(' {
+				current = call{kind: content[i:j], start: i}
+			}
+			i = j - 1
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets at end of file")
+	}
+	return calls, nil
+}
+
+func skipString(content string, i int) (int, error) {
+	q := content[i]
+	if strings.HasPrefix(content[i:], strings.Repeat(string(q), 3)) {
+		end := strings.Index(content[i+3:], strings.Repeat(string(q), 3))
+		if end < 0 {
+			return 0, fmt.Errorf("unterminated string at offset %d", i)
+		}
+		return i + 3 + end + 3, nil
+	}
+	for j := i + 1; j < len(content); j++ {
+		switch content[j] {
+		case '\\':
+			j++
+		case q:
+			return j + 1, nil
+		case '\n':
+			return 0, fmt.Errorf("unterminated string at offset %d", i)
+		}
+	}
+	return 0, fmt.Errorf("unterminated string at offset %d", i)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,204 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package modulefile
+
+import (
+	"strings"
+	"testing"
+
+	"scorex/internal/model"
+)
+
+var (
+	baseV2 = model.ModuleInfo{Version: "2.0.0", Hash: "new", Repo: "https://example.com/base.git"}
+
+	renderedBaseV2 = `# score_base
+bazel_dep(
+    name = "score_base",
+    version = "2.0.0",
+)
+
+git_override(
+    module_name = "score_base",
+    remote = "https://example.com/base.git",
+    commit = "new",
+)
+
+`
+)
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		managed []string
+		modules map[string]model.ModuleInfo
+		want    string
+	}{
+		{
+			name: "comments around blocks",
+			content: `module(name = "app")
+
+# pinned for the 1.0 release
+# score_base
+bazel_dep(name = "score_base", version = "1.0.0")  # keep in sync
+git_override(
+    module_name = "score_base",
+    remote = "https://example.com/base.git",
+    commit = "old",  # 1.0.0
+)
+
+# C/C++ rules
+bazel_dep(name = "rules_cc", version = "0.2.1")
+`,
+			managed: []string{"score_base"},
+			modules: map[string]model.ModuleInfo{"score_base": baseV2},
+			want: `module(name = "app")
+
+# pinned for the 1.0 release
+` + renderedBaseV2 + `# C/C++ rules
+bazel_dep(name = "rules_cc", version = "0.2.1")
+`,
+		},
+		{
+			name: "multi-line git_override",
+			content: `module(name = "app")
+
+bazel_dep(name = "score_base", version = "1.0.0")
+
+git_override(
+    module_name = "score_base",
+    remote = "https://example.com/base.git",
+    commit = "old",
+    patches = [
+        "//patches:fix(1).patch",  # ) in a string and a comment
+    ],
+    patch_strip = 1,
+)
+
+bazel_dep(name = "rules_cc", version = "0.2.1")
+`,
+			managed: []string{"score_base"},
+			modules: map[string]model.ModuleInfo{"score_base": baseV2},
+			want: `module(name = "app")
+
+` + renderedBaseV2 + `bazel_dep(name = "rules_cc", version = "0.2.1")
+`,
+		},
+		{
+			name: "bazel_dep without override",
+			content: `module(name = "app")
+
+bazel_dep(name = "score_base", version = "1.0.0")
+bazel_dep(name = "rules_cc", version = "0.2.1")
+`,
+			managed: []string{"score_base"},
+			modules: map[string]model.ModuleInfo{"score_base": baseV2},
+			want: `module(name = "app")
+
+` + renderedBaseV2 + `bazel_dep(name = "rules_cc", version = "0.2.1")
+`,
+		},
+		{
+			name: "remove a module between others",
+			content: `module(name = "app")
+
+bazel_dep(name = "rules_cc", version = "0.2.1")
+# score_base
+bazel_dep(name = "score_base", version = "1.0.0")
+
+bazel_dep(name = "rules_rust", version = "0.67.0")
+`,
+			managed: []string{"score_base"},
+			want: `module(name = "app")
+
+bazel_dep(name = "rules_cc", version = "0.2.1")
+
+bazel_dep(name = "rules_rust", version = "0.67.0")
+`,
+		},
+		{
+			name: "remove the last module",
+			content: `module(name = "app")
+
+bazel_dep(name = "rules_cc", version = "0.2.1")
+
+# score_base
+bazel_dep(
+    name = "score_base",
+    version = "1.0.0",
+)
+
+git_override(
+    module_name = "score_base",
+    remote = "https://example.com/base.git",
+    commit = "old",
+)
+`,
+			managed: []string{"score_base"},
+			want: `module(name = "app")
+
+bazel_dep(name = "rules_cc", version = "0.2.1")
+
+`,
+		},
+		{
+			name: "add to a file without module blocks",
+			content: `module(name = "app")
+`,
+			modules: map[string]model.ModuleInfo{"score_base": baseV2},
+			want: `module(name = "app")
+
+` + renderedBaseV2,
+		},
+		{
+			name:    "unmanaged modules are kept",
+			content: "bazel_dep(name = \"score_other\", version = \"1.0.0\")\n",
+			managed: []string{"score_base"},
+			want:    "bazel_dep(name = \"score_other\", version = \"1.0.0\")\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Patch(tt.content, tt.managed, tt.modules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Patch() =\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			// CRLF files keep their line endings.
+			crlf := strings.ReplaceAll(tt.content, "\n", "\r\n")
+			got, err = Patch(crlf, tt.managed, tt.modules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := strings.ReplaceAll(tt.want, "\n", "\r\n"); got != want {
+				t.Errorf("Patch() of CRLF input =\n%q\nwant:\n%q", got, want)
+			}
+		})
+	}
+}
+
+func TestPatchErrors(t *testing.T) {
+	for _, content := range []string{
+		"bazel_dep(name = \"score_base\"\n",
+		"bazel_dep(name = \"score_base)\n",
+		"bazel_dep(name = \"score_base\"))\n",
+	} {
+		if _, err := Patch(content, []string{"score_base"}, nil); err == nil {
+			t.Errorf("Patch(%q) succeeded, want an error", content)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package modulefile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"scorex/internal/model"
+)
+
+// Render returns the MODULE.bazel blocks (bazel_dep plus override, depending
+// on the module's pin mode) for the given modules, sorted by module name.
+func Render(modules map[string]model.ModuleInfo) string {
+	names := make([]string, 0, len(modules))
+	for n := range modules {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(renderModule(name, modules[name]))
+	}
+	return b.String()
+}
+
+func renderModule(name string, m model.ModuleInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", name)
+	fmt.Fprintf(&b, "bazel_dep(\n    name = %q,\n    version = %q,\n)\n\n", name, m.Version)
+	switch m.PinMode() {
+	case "registry":
+		// The version is resolved through the configured Bazel registry.
+	case "archive":
+		fmt.Fprintf(
+			&b,
+			"archive_override(\n    module_name = %q,\n    urls = [%q],\n    integrity = %q,\n    strip_prefix = %q,\n)\n\n",
+			name, m.Pin.URL, m.Pin.Integrity, m.Pin.StripPrefix,
+		)
+	case "local":
+		fmt.Fprintf(&b, "local_path_override(\n    module_name = %q,\n    path = %q,\n)\n\n", name, m.Pin.Path)
+	default:
+		fmt.Fprintf(&b, "git_override(\n    module_name = %q,\n    remote = %q,\n    commit = %q,\n)\n\n", name, m.Repo, m.Hash)
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package projectinit
+
+import (
+	"fmt"
+	"strings"
+
+	"scorex/internal/service/templates"
+)
+
+// RequiredPolicy decides what happens when the module selection lacks
+// modules the template requires.
+type RequiredPolicy string
+
+const (
+	RequiredAdd  RequiredPolicy = "add"
+	RequiredFail RequiredPolicy = "fail"
+)
+
+// ParseRequiredPolicy parses the value of --required-modules.
+func ParseRequiredPolicy(s string) (RequiredPolicy, error) {
+	switch p := RequiredPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case RequiredAdd, RequiredFail:
+		return p, nil
+	case "":
+		return RequiredAdd, nil
+	default:
+		return "", fmt.Errorf("invalid required modules policy %q (use add or fail)", s)
+	}
+}
+
+// checkRequired applies policy to the modules tmpl requires but names lacks.
+// It returns names with the missing modules appended, and the added modules.
+func checkRequired(tmpl *templates.Template, names []string, policy RequiredPolicy, preset string) ([]string, []string, error) {
+	missing := tmpl.MissingModules(names)
+	if len(missing) == 0 {
+		return names, nil, nil
+	}
+
+	if policy == RequiredFail {
+		selection := "the module selection"
+		if preset != "" {
+			selection = fmt.Sprintf("module preset %q", preset)
+		}
+		return nil, nil, fmt.Errorf(
+			"template %q requires %s, which %s does not include; select them too, or let scorex add them with --required-modules=add",
+			tmpl.ID, strings.Join(missing, ", "), selection,
+		)
+	}
+	return append(names, missing...), missing, nil
+}
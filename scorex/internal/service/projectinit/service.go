@@ -1,82 +1,226 @@
 package projectinit
 
 import (
+    "context"
     "fmt"
     "path/filepath"
+    "slices"
+    "strings"
+    "time"
 
     "scorex/internal/config"
     "scorex/internal/model"
     "scorex/internal/service/knowngood"
     "scorex/internal/service/module"
     "scorex/internal/service/skeleton"
+    "scorex/internal/service/templates"
 )
 
 // Options represents all inputs required to initialize a new project.
 type Options struct {
-    Modules      []string
+    Modules      []string // module names, optionally with @<commit>, @<tag> or @branch:<name>
     TargetDir    string
     Name         string
     KnownGoodURL string
     BazelVersion string
     ProjectType  string // "Application" or "Module"
     AppType      string // "feo" or "daal"
+    Template     string // template ID; derived from ProjectType/AppType when empty
+    TemplateDir  string // additional template directory, overrides other sources
+    KnownGood    knowngood.LoadOptions // how to fetch and verify known_good.json
+    Policy       knowngood.Policy // age and suite the manifest must have; recorded in scorex.lock
+    Resolver     module.Resolver // resolves modules missing from known_good; defaults to module.DefaultResolver()
+    PinMode      string // module.PinGit (default), module.PinRegistry or module.PinArchive
+    Local        map[string]string // module name -> local checkout relative to the project, for local_path_override
+    ModulePreset string // preset the modules come from, for error messages
+    Required     RequiredPolicy // what to do with modules the template requires; defaults to RequiredAdd
+    RegistryURL  string // Bazel registry checked by module.PinRegistry
+    Jobs         int // modules resolved concurrently, defaults to module.DefaultJobs
 	IncludeDevcontainer bool
+    DryRun       bool // only compute Result.Changes, write nothing
+    Diff         bool // like DryRun; callers show diffs of Result.Changes
+    OnConflict   skeleton.ConflictPolicy // defaults to skeleton.ConflictFail
+
+    KnownGoodOverlays []string                  // layered over KnownGoodURL in order, later ones win
+    NoDependencies    bool                      // do not add the S-CORE modules the selected modules depend on
+    ConfirmedModules  []string                  // names the user confirmed, not rejected as typos of known modules
+    ResolveConflict   skeleton.ConflictResolver // used with skeleton.ConflictAsk
 }
 
 // Result contains information about the generated project.
 type Result struct {
     TargetDir       string
+    KnownGood       *model.KnownGood // effective manifest the modules were resolved against
     SelectedModules map[string]model.ModuleInfo
+    Changes         []skeleton.FileChange
+    Skipped         []string // existing files left untouched
+    BackedUp        []skeleton.Backup // existing files moved aside before being replaced
+    Required        []string // modules added because the template requires them
+    Dependencies    []string // modules added because selected modules require them
+    Warnings        []string // problems found while resolving modules
 }
 
 // Run performs the full project initialization flow based on the provided options.
-func Run(opts Options) (*Result, error) {
+// Cancelling ctx aborts it; files already written to the target directory are
+// then reverted.
+func Run(ctx context.Context, opts Options) (*Result, error) {
     if len(opts.Modules) == 0 {
         return nil, fmt.Errorf("at least one module must be set")
     }
 
-    kg, err := knowngood.Load(opts.KnownGoodURL)
+    tmpl, err := lookupTemplate(opts)
+    if err != nil {
+        return nil, err
+    }
+
+    kg, err := knowngood.LoadMerged(ctx, opts.KnownGoodURL, opts.KnownGoodOverlays, opts.KnownGood)
     if err != nil {
         return nil, fmt.Errorf("error loading known_good.json: %w", err)
     }
+    policyWarnings, err := opts.Policy.Check(kg.KnownGood, time.Now())
+    if err != nil {
+        return nil, err
+    }
 
-    selected, err := module.ResolveModules(opts.Modules, kg.Modules)
+    names, refs, err := module.ParseModuleArgs(opts.Modules)
+    if err != nil {
+        return nil, err
+    }
+    names, required, err := checkRequired(tmpl, names, opts.Required, opts.ModulePreset)
     if err != nil {
         return nil, err
     }
 
+    presets, err := config.LoadModulePresets()
+    if err != nil {
+        return nil, err
+    }
+    confirmed := make([]string, 0, len(opts.ConfirmedModules))
+    for _, name := range opts.ConfirmedModules {
+        confirmed = append(confirmed, module.NormalizeName(name))
+    }
+
+    resolved, err := module.ResolveAll(ctx, names, kg.Modules, module.Options{
+        Offline:        opts.KnownGood.Offline,
+        Fallback:       opts.Resolver,
+        Cache:          module.OpenCache(opts.KnownGood.CacheDir),
+        NoDependencies: opts.NoDependencies,
+        Overrides:      refs,
+        SuggestFrom:    config.PresetModules(presets),
+        Confirmed:      confirmed,
+        Jobs:           opts.Jobs,
+    })
+    if err != nil {
+        return nil, err
+    }
+    if err := module.ApplyPinMode(ctx, resolved, module.PinOptions{
+        Mode:        opts.PinMode,
+        RegistryURL: opts.RegistryURL,
+        Offline:     opts.KnownGood.Offline,
+    }); err != nil {
+        return nil, err
+    }
+
     targetDir := filepath.Join(opts.TargetDir, opts.Name)
 
+    local := make(map[string]string, len(opts.Local))
+    for name, path := range opts.Local {
+        local[module.NormalizeName(name)] = path
+    }
+    if err := module.ApplyLocalPaths(resolved, local, targetDir); err != nil {
+        return nil, fmt.Errorf("local checkout: %w", err)
+    }
+    selected := module.ModuleInfos(resolved)
+
     props := skeleton.Properties{
         ProjectName:     opts.Name,
         SelectedModules: selected,
         BazelVersion:    opts.BazelVersion,
         TargetDir:       targetDir,
-        IsApplication:   opts.ProjectType == "Application",
-        UseFeo:          opts.AppType == "feo",
+        IsApplication:   tmpl.ProjectType == "Application",
+        UseFeo:          tmpl.AppType == "feo",
 		IncludeDevcontainer: opts.IncludeDevcontainer,
+        Template:        tmpl,
     }
 
-    if err := skeleton.Generate(props); err != nil {
+    files, err := skeleton.Render(props)
+    if err != nil {
         return nil, err
     }
 
     cfg := &config.ProjectConfig{
         ProjectName:  opts.Name,
-        Template:     templateFor(opts.ProjectType, opts.AppType),
+        Template:     tmpl.ID,
         BazelVersion: opts.BazelVersion,
-        KnownGoodURL: opts.KnownGoodURL,
-        Modules:      opts.Modules,
+        KnownGoodURL: config.ProjectPath(targetDir, opts.KnownGoodURL),
+        KnownGoodSHA256: opts.KnownGood.ExpectedSHA256,
+        KnownGoodOverlays: config.ProjectPaths(targetDir, opts.KnownGoodOverlays),
+        Modules:      names,
+        PinMode:      pinMode(opts.PinMode),
+        Overrides:    module.FormatRefs(refs),
+        Local:        local,
     }
 
-    if err := config.WriteProjectConfig(targetDir, cfg); err != nil {
+    cfgData, err := config.MarshalProjectConfig(cfg)
+    if err != nil {
         return nil, fmt.Errorf("writing scorex config: %w", err)
     }
+    files = append(files, skeleton.File{Path: config.DefaultConfigFileName, Content: cfgData})
 
-    return &Result{
+    lockData, err := config.MarshalLock(config.NewLock(kg, opts.Policy, resolved))
+    if err != nil {
+        return nil, fmt.Errorf("writing scorex lock: %w", err)
+    }
+    files = append(files, skeleton.File{Path: config.DefaultLockFileName, Content: lockData})
+
+    changes, err := skeleton.Plan(targetDir, files)
+    if err != nil {
+        return nil, err
+    }
+
+    result := &Result{
         TargetDir:       targetDir,
+        KnownGood:       kg.KnownGood,
         SelectedModules: selected,
-    }, nil
+        Changes:         changes,
+        Required:        required,
+        Dependencies:    module.Dependencies(resolved),
+        Warnings:        slices.Concat(policyWarnings, kg.WarningsFor(resolved), module.Warnings(resolved)),
+    }
+    if opts.DryRun || opts.Diff {
+        return result, nil
+    }
+
+    policy := opts.OnConflict
+    if policy == "" {
+        policy = skeleton.ConflictFail
+    }
+    written, err := skeleton.WriteChanges(ctx, targetDir, changes, policy, opts.ResolveConflict)
+    if err != nil {
+        return nil, err
+    }
+    result.Skipped = written.Skipped
+    result.BackedUp = written.BackedUp
+
+    return result, nil
+}
+
+// lookupTemplate picks the template requested in opts from all template sources.
+func lookupTemplate(opts Options) (*templates.Template, error) {
+    set, err := templates.Load(opts.TemplateDir)
+    if err != nil {
+        return nil, err
+    }
+
+    id := opts.Template
+    if id == "" {
+        id = templateFor(opts.ProjectType, opts.AppType)
+    }
+    tmpl, ok := set.Lookup(id)
+    if !ok {
+        return nil, fmt.Errorf("unknown template %q (known: %s)", id, strings.Join(set.IDs(), ", "))
+    }
+    return tmpl, nil
 }
 
 func templateFor(projectType, appType string) string {
@@ -92,3 +236,12 @@ func templateFor(projectType, appType string) string {
         return "daal_app"
     }
 }
+
+// pinMode returns the pin mode recorded in scorex.json; the default git mode
+// is not recorded.
+func pinMode(mode string) string {
+    if mode == module.PinGit {
+        return ""
+    }
+    return mode
+}
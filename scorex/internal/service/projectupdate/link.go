@@ -0,0 +1,117 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package projectupdate
+
+import (
+	"context"
+	"fmt"
+
+	"scorex/internal/config"
+	"scorex/internal/model"
+	"scorex/internal/service/module"
+)
+
+// LinkOptions represents the inputs for linking a module to a local checkout.
+type LinkOptions struct {
+	ProjectDir  string
+	Module      string
+	Path        string // local checkout, relative to ProjectDir; unused by Unlink
+	RegistryURL string // Bazel registry checked by module.PinRegistry on Unlink
+	Offline     bool
+}
+
+// Link switches a module of the project to a local_path_override on a local
+// checkout and records the checkout in scorex.json.
+func Link(opts LinkOptions) (string, model.ResolvedModule, error) {
+	name := module.NormalizeName(opts.Module)
+
+	cfg, lock, err := readForLink(opts.ProjectDir, name)
+	if err != nil {
+		return "", model.ResolvedModule{}, err
+	}
+
+	resolved := map[string]model.ResolvedModule{name: lock.Modules[name]}
+	if err := module.ApplyLocalPaths(resolved, map[string]string{name: opts.Path}, opts.ProjectDir); err != nil {
+		return "", model.ResolvedModule{}, err
+	}
+
+	if cfg.Local == nil {
+		cfg.Local = make(map[string]string)
+	}
+	cfg.Local[name] = resolved[name].Pin.Path
+	return name, resolved[name], writeLinked(opts.ProjectDir, cfg, lock, resolved)
+}
+
+// Unlink switches a linked module back to the pin mode of the project and
+// drops its local checkout from scorex.json.
+func Unlink(ctx context.Context, opts LinkOptions) (string, model.ResolvedModule, error) {
+	name := module.NormalizeName(opts.Module)
+
+	cfg, lock, err := readForLink(opts.ProjectDir, name)
+	if err != nil {
+		return "", model.ResolvedModule{}, err
+	}
+	if _, ok := cfg.Local[name]; !ok {
+		return "", model.ResolvedModule{}, fmt.Errorf("module %q is not linked to a local checkout", name)
+	}
+
+	resolved := map[string]model.ResolvedModule{name: lock.Modules[name]}
+	if err := module.ApplyPinMode(ctx, resolved, module.PinOptions{
+		Mode:        cfg.PinMode,
+		RegistryURL: opts.RegistryURL,
+		Offline:     opts.Offline,
+	}); err != nil {
+		return "", model.ResolvedModule{}, err
+	}
+
+	delete(cfg.Local, name)
+	return name, resolved[name], writeLinked(opts.ProjectDir, cfg, lock, resolved)
+}
+
+// readForLink reads the project's scorex.json and scorex.lock and checks that
+// name is one of its resolved modules.
+func readForLink(projectDir, name string) (*config.ProjectConfig, *model.Lock, error) {
+	cfg, err := config.ReadProjectConfig(projectDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading scorex config: %w", err)
+	}
+	lock, err := config.ReadLock(projectDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading scorex lock (run update to create it): %w", err)
+	}
+	if _, ok := lock.Modules[name]; !ok {
+		return nil, nil, fmt.Errorf("module %q is not part of the project", name)
+	}
+	return cfg, lock, nil
+}
+
+// writeLinked patches the blocks of the modules in resolved and writes
+// scorex.json and scorex.lock.
+func writeLinked(projectDir string, cfg *config.ProjectConfig, lock *model.Lock, resolved map[string]model.ResolvedModule) error {
+	managed := make([]string, 0, len(resolved))
+	for name, rm := range resolved {
+		managed = append(managed, name)
+		lock.Modules[name] = rm
+	}
+	if _, err := PatchModuleFile(projectDir, managed, module.ModuleInfos(resolved)); err != nil {
+		return err
+	}
+
+	if err := config.WriteProjectConfig(projectDir, cfg); err != nil {
+		return fmt.Errorf("writing scorex config: %w", err)
+	}
+	if err := config.WriteLock(projectDir, lock); err != nil {
+		return fmt.Errorf("writing scorex lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package projectupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	"scorex/internal/config"
+	"scorex/internal/model"
+	"scorex/internal/service/knowngood"
+	"scorex/internal/service/module"
+	"scorex/internal/service/templates"
+)
+
+// ModuleOptions represents the inputs for adding or removing a single module.
+type ModuleOptions struct {
+	ProjectDir   string
+	Module       string                // module name, for AddModule optionally with @<ref>
+	KnownGoodURL string                // overrides the URL stored in scorex.json when set
+	Overlays     []string              // override the known_good overlays stored in scorex.json when set
+	KnownGood    knowngood.LoadOptions // ExpectedSHA256 overrides the pin in scorex.json
+	Resolver     module.Resolver       // resolves modules missing from known_good
+
+	NoDependencies bool   // do not add the S-CORE modules the module depends on
+	RegistryURL    string // Bazel registry checked by module.PinRegistry
+
+	// TemplateDir is an additional template directory, searched for the
+	// template recorded in scorex.json when removing a module.
+	TemplateDir string
+	// Force removes modules the project's template requires.
+	Force bool
+}
+
+// AddResult contains information about an added module.
+type AddResult struct {
+	Name         string
+	Module       model.ResolvedModule
+	Dependencies []string // modules added because the module requires them
+	Warnings     []string // problems found while resolving modules
+}
+
+// AddModule resolves a module, adds its blocks to MODULE.bazel and records
+// it in scorex.json and scorex.lock. S-CORE modules it depends on are added
+// as well unless the project already has them.
+func AddModule(ctx context.Context, opts ModuleOptions) (*AddResult, error) {
+	name, ref, err := module.ParseModuleArg(opts.Module)
+	if err != nil {
+		return nil, err
+	}
+	var overrides map[string]module.Ref
+	if ref != nil {
+		overrides = map[string]module.Ref{name: *ref}
+	}
+
+	cfg, err := config.ReadProjectConfig(opts.ProjectDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading scorex config: %w", err)
+	}
+	if indexOfModule(cfg.Modules, name) >= 0 {
+		return nil, fmt.Errorf("module %q is already part of the project (use update to re-resolve it)", name)
+	}
+
+	// Adding a single module does not move the project to another manifest.
+	kgCfg := *cfg
+	loadOpts := applyKnownGood(&kgCfg, opts.ProjectDir, opts.KnownGoodURL, opts.Overlays, opts.KnownGood)
+	base := config.ResolveProjectPath(opts.ProjectDir, kgCfg.KnownGoodURL)
+	overlays := config.ResolveProjectPaths(opts.ProjectDir, kgCfg.KnownGoodOverlays)
+	kg, err := knowngood.LoadMerged(ctx, base, overlays, loadOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error loading known_good.json: %w", err)
+	}
+	policy, err := lockedPolicy(opts.ProjectDir)
+	if err != nil {
+		return nil, err
+	}
+	policyWarnings, err := policy.Check(kg.KnownGood, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	presets, err := config.LoadModulePresets()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := module.ResolveAll(ctx, []string{name}, kg.Modules, module.Options{
+		Offline:        opts.KnownGood.Offline,
+		Fallback:       opts.Resolver,
+		Cache:          module.OpenCache(opts.KnownGood.CacheDir),
+		NoDependencies: opts.NoDependencies,
+		Overrides:      overrides,
+		SuggestFrom:    config.PresetModules(presets),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := config.ReadLock(opts.ProjectDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		lock, err = config.NewLock(kg, policy, map[string]model.ResolvedModule{}), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading scorex lock: %w", err)
+	}
+
+	// Dependencies the project already has keep their pins.
+	added := make(map[string]model.ResolvedModule, len(resolved))
+	for dep, rm := range resolved {
+		if dep != name {
+			if existing, ok := lock.Modules[dep]; ok {
+				if len(existing.RequiredBy) > 0 {
+					for _, by := range rm.RequiredBy {
+						if indexOfModule(existing.RequiredBy, by) < 0 {
+							existing.RequiredBy = append(existing.RequiredBy, by)
+						}
+					}
+					lock.Modules[dep] = existing
+				}
+				continue
+			}
+			if indexOfModule(cfg.Modules, dep) >= 0 {
+				continue
+			}
+		}
+		added[dep] = rm
+	}
+	if err := module.ApplyPinMode(ctx, added, module.PinOptions{
+		Mode:        cfg.PinMode,
+		RegistryURL: opts.RegistryURL,
+		Offline:     opts.KnownGood.Offline,
+	}); err != nil {
+		return nil, err
+	}
+	for dep, rm := range added {
+		lock.Modules[dep] = rm
+	}
+
+	managed := make([]string, 0, len(added))
+	for dep := range added {
+		managed = append(managed, dep)
+	}
+	if _, err := PatchModuleFile(opts.ProjectDir, managed, module.ModuleInfos(added)); err != nil {
+		return nil, err
+	}
+
+	cfg.Modules = append(cfg.Modules, name)
+	if ref != nil {
+		if cfg.Overrides == nil {
+			cfg.Overrides = make(map[string]string)
+		}
+		cfg.Overrides[name] = ref.String()
+	}
+	if err := config.WriteProjectConfig(opts.ProjectDir, cfg); err != nil {
+		return nil, fmt.Errorf("writing scorex config: %w", err)
+	}
+	if err := config.WriteLock(opts.ProjectDir, lock); err != nil {
+		return nil, fmt.Errorf("writing scorex lock: %w", err)
+	}
+	return &AddResult{
+		Name:         name,
+		Module:       added[name],
+		Dependencies: module.Dependencies(added),
+		Warnings:     slices.Concat(policyWarnings, kg.WarningsFor(added), module.Warnings(added)),
+	}, nil
+}
+
+// RemoveModule drops a module's blocks from MODULE.bazel and the module from
+// scorex.json and scorex.lock, together with the dependencies no remaining
+// module requires. Modules the project's template requires are kept unless
+// opts.Force is set. It returns the names of all removed modules.
+func RemoveModule(opts ModuleOptions) ([]string, error) {
+	name := module.NormalizeName(opts.Module)
+
+	cfg, err := config.ReadProjectConfig(opts.ProjectDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading scorex config: %w", err)
+	}
+	idx := indexOfModule(cfg.Modules, name)
+	if idx < 0 {
+		return nil, fmt.Errorf("module %q is not part of the project", name)
+	}
+	cfg.Modules = append(cfg.Modules[:idx], cfg.Modules[idx+1:]...)
+	delete(cfg.Overrides, name)
+
+	// Projects generated before scorex.lock existed have no dependencies to
+	// clean up.
+	lock, err := config.ReadLock(opts.ProjectDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("reading scorex lock: %w", err)
+	}
+	removed := []string{name}
+	if lock != nil {
+		removed = removeFromLock(lock, cfg.Modules, name)
+	}
+	if !opts.Force {
+		if err := checkTemplateRequires(cfg.Template, opts.TemplateDir, removed); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := PatchModuleFile(opts.ProjectDir, removed, nil); err != nil {
+		return nil, err
+	}
+	for _, r := range removed {
+		delete(cfg.Local, r)
+	}
+
+	if err := config.WriteProjectConfig(opts.ProjectDir, cfg); err != nil {
+		return nil, fmt.Errorf("writing scorex config: %w", err)
+	}
+	if lock != nil {
+		if err := config.WriteLock(opts.ProjectDir, lock); err != nil {
+			return nil, fmt.Errorf("writing scorex lock: %w", err)
+		}
+	}
+	return removed, nil
+}
+
+// checkTemplateRequires rejects removing modules the template with the given
+// ID requires. Projects generated before the template was recorded in
+// scorex.json are not checked.
+func checkTemplateRequires(id, templateDir string, removed []string) error {
+	if id == "" {
+		return nil
+	}
+	set, err := templates.Load(templateDir)
+	if err != nil {
+		return err
+	}
+	tmpl, ok := set.Lookup(id)
+	if !ok {
+		return fmt.Errorf("template %q recorded in scorex.json is unknown; pass the directory it comes from with --template-dir, or --force to skip the check", id)
+	}
+	var required []string
+	for _, r := range removed {
+		if slices.Contains(tmpl.RequiredModules, r) {
+			required = append(required, r)
+		}
+	}
+	if len(required) > 0 {
+		return fmt.Errorf("template %q requires %s; use --force to remove anyway", id, strings.Join(required, ", "))
+	}
+	return nil
+}
+
+// removeFromLock deletes name from lock, and then every dependency that is
+// neither selected nor required by another module anymore.
+func removeFromLock(lock *model.Lock, selected []string, name string) []string {
+	removed := []string{name}
+	for i := 0; i < len(removed); i++ {
+		gone := removed[i]
+		delete(lock.Modules, gone)
+		for dep, rm := range lock.Modules {
+			j := indexOfModule(rm.RequiredBy, gone)
+			if j < 0 {
+				continue
+			}
+			rm.RequiredBy = append(rm.RequiredBy[:j], rm.RequiredBy[j+1:]...)
+			if len(rm.RequiredBy) == 0 && indexOfModule(selected, dep) < 0 {
+				removed = append(removed, dep)
+			}
+			lock.Modules[dep] = rm
+		}
+	}
+	return removed
+}
+
+// indexOfModule finds name in modules, ignoring a missing score_ prefix.
+func indexOfModule(modules []string, name string) int {
+	for i, m := range modules {
+		if module.NormalizeName(m) == name {
+			return i
+		}
+	}
+	return -1
+}
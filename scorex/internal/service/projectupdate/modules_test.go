@@ -0,0 +1,87 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package projectupdate
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"scorex/internal/config"
+)
+
+// newProject writes a project generated from the given template with the
+// given modules.
+func newProject(t *testing.T, template string, modules ...string) string {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir()) // no user templates
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "MODULE.bazel"), []byte("module(name = \"app\")\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.ProjectConfig{ProjectName: "app", Template: template, Modules: modules}
+	if err := config.WriteProjectConfig(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRemoveModuleRequiredByTemplate(t *testing.T) {
+	dir := newProject(t, "daal_app", "score_inc_daal", "score_baselibs")
+
+	_, err := RemoveModule(ModuleOptions{ProjectDir: dir, Module: "inc_daal"})
+	if err == nil || !strings.Contains(err.Error(), `template "daal_app" requires score_inc_daal`) {
+		t.Fatalf("RemoveModule(score_inc_daal) = %v, want an error about the template", err)
+	}
+
+	if _, err := RemoveModule(ModuleOptions{ProjectDir: dir, Module: "score_baselibs"}); err != nil {
+		t.Fatalf("RemoveModule(score_baselibs) failed: %v", err)
+	}
+	if _, err := RemoveModule(ModuleOptions{ProjectDir: dir, Module: "score_inc_daal", Force: true}); err != nil {
+		t.Fatalf("RemoveModule(score_inc_daal) with Force failed: %v", err)
+	}
+	cfg, err := config.ReadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Modules) != 0 {
+		t.Errorf("modules after removing both = %v, want none", cfg.Modules)
+	}
+}
+
+func TestRemoveModuleUnknownTemplate(t *testing.T) {
+	dir := newProject(t, "custom_app", "score_baselibs")
+
+	_, err := RemoveModule(ModuleOptions{ProjectDir: dir, Module: "score_baselibs"})
+	if err == nil || !strings.Contains(err.Error(), "--template-dir") {
+		t.Fatalf("RemoveModule() = %v, want an error pointing to --template-dir", err)
+	}
+	removed, err := RemoveModule(ModuleOptions{ProjectDir: dir, Module: "score_baselibs", Force: true})
+	if err != nil {
+		t.Fatalf("RemoveModule() with Force failed: %v", err)
+	}
+	if !slices.Equal(removed, []string{"score_baselibs"}) {
+		t.Errorf("RemoveModule() = %v, want [score_baselibs]", removed)
+	}
+}
+
+func TestRemoveModuleWithoutTemplate(t *testing.T) {
+	// scorex.json of projects generated before the template was recorded
+	dir := newProject(t, "", "score_inc_daal")
+
+	if _, err := RemoveModule(ModuleOptions{ProjectDir: dir, Module: "score_inc_daal"}); err != nil {
+		t.Fatalf("RemoveModule() failed: %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package projectupdate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"scorex/internal/config"
+	"scorex/internal/model"
+	"scorex/internal/service/knowngood"
+	"scorex/internal/service/module"
+	"scorex/internal/service/modulefile"
+)
+
+// Options represents all inputs required to update an existing project.
+type Options struct {
+	ProjectDir   string
+	KnownGoodURL string                // overrides the URL stored in scorex.json when set
+	Overlays     []string              // replace the known_good overlays stored in scorex.json when set
+	NoOverlays   bool                  // drop the known_good overlays stored in scorex.json
+	Policy       knowngood.Policy      // set fields override the policy recorded in scorex.lock
+	KnownGood    knowngood.LoadOptions // ExpectedSHA256 overrides the pin in scorex.json
+	Resolver     module.Resolver       // resolves modules missing from known_good
+
+	NoDependencies bool   // do not add the S-CORE modules the selected modules depend on
+	PinMode        string // overrides the pin mode stored in scorex.json when set
+	RegistryURL    string // Bazel registry checked by module.PinRegistry
+	Jobs           int    // modules resolved concurrently, defaults to module.DefaultJobs
+
+	// Refs changes module overrides: name@ref pins a module to ref, a plain
+	// name returns it to its known_good entry.
+	Refs []string
+}
+
+// Result contains information about the updated project.
+type Result struct {
+	ProjectDir      string
+	SelectedModules map[string]model.ModuleInfo
+	Changed         bool
+	KnownGood       *model.KnownGood // effective manifest the modules were resolved against
+	Policy          knowngood.Policy // policy the manifest was checked against
+	Dependencies    []string         // modules added because selected modules require them
+	Warnings        []string         // problems found while resolving modules
+}
+
+// Run re-resolves the modules recorded in the project's scorex.json,
+// rewrites their blocks in MODULE.bazel and refreshes scorex.lock. The
+// project is only written once all modules are resolved; cancelling ctx
+// before that leaves it unchanged.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	cfg, err := config.ReadProjectConfig(opts.ProjectDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading scorex config: %w", err)
+	}
+	if opts.NoOverlays {
+		cfg.KnownGoodOverlays = nil
+	}
+	loadOpts := applyKnownGood(cfg, opts.ProjectDir, opts.KnownGoodURL, opts.Overlays, opts.KnownGood)
+
+	base := config.ResolveProjectPath(opts.ProjectDir, cfg.KnownGoodURL)
+	overlays := config.ResolveProjectPaths(opts.ProjectDir, cfg.KnownGoodOverlays)
+	kg, err := knowngood.LoadMerged(ctx, base, overlays, loadOpts)
+	if err != nil {
+		return nil, fmt.Errorf("error loading known_good.json: %w", err)
+	}
+	policy, err := lockedPolicy(opts.ProjectDir)
+	if err != nil {
+		return nil, err
+	}
+	policy = opts.Policy.Over(policy)
+	policyWarnings, err := policy.Check(kg.KnownGood, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyRefs(cfg, opts.Refs); err != nil {
+		return nil, err
+	}
+	refs, err := module.ParseRefs(cfg.Overrides)
+	if err != nil {
+		return nil, fmt.Errorf("reading scorex config: %w", err)
+	}
+
+	resolved, err := module.ResolveAll(ctx, cfg.Modules, kg.Modules, module.Options{
+		Offline:        opts.KnownGood.Offline,
+		Fallback:       opts.Resolver,
+		Cache:          module.OpenCache(opts.KnownGood.CacheDir),
+		NoDependencies: opts.NoDependencies,
+		Overrides:      refs,
+		Confirmed:      normalizeNames(cfg.Modules), // checked for typos when they were added
+		Jobs:           opts.Jobs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if opts.PinMode != "" {
+		cfg.PinMode = opts.PinMode
+		if cfg.PinMode == module.PinGit {
+			cfg.PinMode = ""
+		}
+	}
+	if err := module.ApplyPinMode(ctx, resolved, module.PinOptions{
+		Mode:        cfg.PinMode,
+		RegistryURL: opts.RegistryURL,
+		Offline:     opts.KnownGood.Offline,
+	}); err != nil {
+		return nil, err
+	}
+	if err := module.ApplyLocalPaths(resolved, cfg.Local, opts.ProjectDir); err != nil {
+		return nil, fmt.Errorf("local checkout: %w", err)
+	}
+	selected := module.ModuleInfos(resolved)
+
+	// Dependencies added by an earlier run are managed as well, so the ones
+	// no longer required are removed.
+	managed := append([]string{}, cfg.Modules...)
+	if lock, err := config.ReadLock(opts.ProjectDir); err == nil {
+		for name := range lock.Modules {
+			managed = append(managed, name)
+		}
+	}
+
+	changed, err := PatchModuleFile(opts.ProjectDir, managed, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.WriteProjectConfig(opts.ProjectDir, cfg); err != nil {
+		return nil, fmt.Errorf("writing scorex config: %w", err)
+	}
+	if err := config.WriteLock(opts.ProjectDir, config.NewLock(kg, policy, resolved)); err != nil {
+		return nil, fmt.Errorf("writing scorex lock: %w", err)
+	}
+
+	return &Result{
+		ProjectDir:      opts.ProjectDir,
+		SelectedModules: selected,
+		Changed:         changed,
+		KnownGood:       kg.KnownGood,
+		Policy:          policy,
+		Dependencies:    module.Dependencies(resolved),
+		Warnings:        slices.Concat(policyWarnings, kg.WarningsFor(resolved), module.Warnings(resolved)),
+	}, nil
+}
+
+// lockedPolicy returns the known_good policy recorded in the project's
+// scorex.lock, if any.
+func lockedPolicy(dir string) (knowngood.Policy, error) {
+	lock, err := config.ReadLock(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return knowngood.Policy{}, nil
+	}
+	if err != nil {
+		return knowngood.Policy{}, fmt.Errorf("reading scorex lock: %w", err)
+	}
+	policy, err := knowngood.PolicyFromLock(lock.KnownGood.Policy)
+	if err != nil {
+		return knowngood.Policy{}, fmt.Errorf("reading scorex lock: %w", err)
+	}
+	return policy, nil
+}
+
+// applyKnownGood switches cfg of the project in projectDir to the given
+// known_good.json URL, overlays and checksum, if set, and returns the options
+// to load it with. A checksum pinned in scorex.json only applies as long as
+// the URL stays the same. Local paths are recorded relative to projectDir.
+func applyKnownGood(cfg *config.ProjectConfig, projectDir, url string, overlays []string, opts knowngood.LoadOptions) knowngood.LoadOptions {
+	if url != "" {
+		if url = config.ProjectPath(projectDir, url); url != cfg.KnownGoodURL {
+			cfg.KnownGoodURL = url
+			cfg.KnownGoodSHA256 = ""
+		}
+	}
+	if len(overlays) > 0 {
+		cfg.KnownGoodOverlays = config.ProjectPaths(projectDir, overlays)
+	}
+	if cfg.KnownGoodURL == "" {
+		cfg.KnownGoodURL = config.DefaultKnownGoodURL
+	}
+	if opts.ExpectedSHA256 != "" {
+		cfg.KnownGoodSHA256 = opts.ExpectedSHA256
+	}
+	opts.ExpectedSHA256 = cfg.KnownGoodSHA256
+	return opts
+}
+
+// applyRefs records the overrides given as module arguments in cfg.
+func applyRefs(cfg *config.ProjectConfig, args []string) error {
+	for _, arg := range args {
+		name, ref, err := module.ParseModuleArg(arg)
+		if err != nil {
+			return err
+		}
+		if indexOfModule(cfg.Modules, name) < 0 {
+			return fmt.Errorf("module %q is not part of the project (use add-module to add it)", name)
+		}
+		if ref == nil {
+			delete(cfg.Overrides, name)
+			continue
+		}
+		if cfg.Overrides == nil {
+			cfg.Overrides = make(map[string]string)
+		}
+		cfg.Overrides[name] = ref.String()
+	}
+	return nil
+}
+
+// PatchModuleFile rewrites the blocks of the managed modules in the project's
+// MODULE.bazel with the given resolved modules. It reports whether the file
+// content changed.
+func PatchModuleFile(projectDir string, managed []string, selected map[string]model.ModuleInfo) (bool, error) {
+	path := filepath.Join(projectDir, modulefile.FileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	// scorex.json may hold names without the score_ prefix.
+	names := make([]string, 0, len(managed))
+	for _, n := range managed {
+		names = append(names, module.NormalizeName(n))
+	}
+
+	patched, err := modulefile.Patch(string(data), names, selected)
+	if err != nil {
+		return false, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if patched == string(data) {
+		return false, nil
+	}
+	return true, os.WriteFile(path, []byte(patched), 0o644)
+}
+
+func normalizeNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		out = append(out, module.NormalizeName(name))
+	}
+	return out
+}
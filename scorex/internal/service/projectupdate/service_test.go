@@ -0,0 +1,80 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package projectupdate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scorex/internal/config"
+	"scorex/internal/service/knowngood"
+)
+
+const testKnownGood = `{"modules": {"score_baselibs": {
+	"version": "0.1.0",
+	"hash": "0123456789abcdef0123456789abcdef01234567",
+	"repo": "https://github.com/eclipse-score/baselibs.git"
+}}}`
+
+// TestRunLocalKnownGood updates a project whose known_good.json is a local
+// file recorded relative to the project, from another working directory.
+func TestRunLocalKnownGood(t *testing.T) {
+	dir := newProject(t, "", "score_baselibs")
+	if err := os.WriteFile(filepath.Join(dir, "kg.json"), []byte(testKnownGood), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.ReadProjectConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.KnownGoodURL = "kg.json"
+	if err := config.WriteProjectConfig(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Run(context.Background(), Options{
+		ProjectDir:     dir,
+		NoDependencies: true,
+		KnownGood:      knowngood.LoadOptions{CacheDir: t.TempDir()},
+	})
+	if err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	if got := result.SelectedModules["score_baselibs"].Version; got != "0.1.0" {
+		t.Errorf("score_baselibs version = %q, want 0.1.0", got)
+	}
+}
+
+func TestApplyKnownGoodRecordsProjectPath(t *testing.T) {
+	projectDir := filepath.Join(t.TempDir(), "proj")
+	kgPath := filepath.Join(filepath.Dir(projectDir), "kg.json")
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := filepath.Rel(wd, kgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.ProjectConfig{KnownGoodURL: "../kg.json", KnownGoodSHA256: "pinned"}
+	opts := applyKnownGood(cfg, projectDir, rel, nil, knowngood.LoadOptions{})
+	if cfg.KnownGoodURL != "../kg.json" {
+		t.Errorf("KnownGoodURL = %q, want ../kg.json", cfg.KnownGoodURL)
+	}
+	if opts.ExpectedSHA256 != "pinned" {
+		t.Errorf("ExpectedSHA256 = %q, want the pin kept for the same file", opts.ExpectedSHA256)
+	}
+}
@@ -0,0 +1,189 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package skeleton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ConflictPolicy decides what happens to an existing file whose content
+// differs from the rendered one.
+type ConflictPolicy string
+
+const (
+	ConflictFail      ConflictPolicy = "fail"
+	ConflictSkip      ConflictPolicy = "skip"
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	ConflictBackup    ConflictPolicy = "backup"
+	// ConflictAsk defers the decision to a ConflictResolver per file.
+	ConflictAsk ConflictPolicy = "ask"
+)
+
+// BackupSuffix is appended to files kept under ConflictBackup. If that name
+// is taken, e.g. by the backup of an earlier run, a counter is added:
+// .orig.1, .orig.2 and so on.
+const BackupSuffix = ".orig"
+
+// ConflictResolver returns the policy for a single conflicting file. It must
+// not return ConflictAsk.
+type ConflictResolver func(c FileChange) (ConflictPolicy, error)
+
+// ParseConflictPolicy parses the value of --on-conflict.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case ConflictFail, ConflictSkip, ConflictOverwrite, ConflictBackup:
+		return p, nil
+	case "":
+		return ConflictFail, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q (use fail, skip, overwrite or backup)", s)
+	}
+}
+
+// Conflicts returns all changes that would modify an existing file.
+func Conflicts(changes []FileChange) []FileChange {
+	var out []FileChange
+	for _, c := range changes {
+		if c.Kind == ChangeModify {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Backup is an existing file that was moved aside before being replaced.
+type Backup struct {
+	Path string // the replaced file
+	To   string // where its previous content is kept
+}
+
+// WriteResult reports which conflicting files were skipped or backed up.
+type WriteResult struct {
+	Skipped  []string
+	BackedUp []Backup
+}
+
+// WriteChanges writes planned changes below targetDir, applying policy to
+// files that already exist with different content. Under ConflictFail nothing
+// is written if there is any conflict. Unchanged files are not touched. If
+// ctx is cancelled or a write fails, the files and directories written so
+// far are reverted, so targetDir is left as it was.
+func WriteChanges(ctx context.Context, targetDir string, changes []FileChange, policy ConflictPolicy, resolve ConflictResolver) (*WriteResult, error) {
+	if conflicts := Conflicts(changes); policy == ConflictFail && len(conflicts) > 0 {
+		paths := make([]string, 0, len(conflicts))
+		for _, c := range conflicts {
+			paths = append(paths, filepath.ToSlash(c.Path))
+		}
+		return nil, fmt.Errorf(
+			"refusing to overwrite existing files in %s: %s (use --on-conflict=skip|overwrite|backup)",
+			targetDir, strings.Join(paths, ", "),
+		)
+	}
+
+	// Decide about every conflict before the first write, so an abort in
+	// interactive mode leaves the directory untouched.
+	decisions := make(map[string]ConflictPolicy)
+	for _, c := range Conflicts(changes) {
+		p := policy
+		if p == ConflictAsk {
+			if resolve == nil {
+				return nil, fmt.Errorf("no conflict resolver for %s", c.Path)
+			}
+			var err error
+			if p, err = resolve(c); err != nil {
+				return nil, err
+			}
+		}
+		if p == ConflictFail {
+			return nil, fmt.Errorf("refusing to overwrite existing file %s", filepath.Join(targetDir, c.Path))
+		}
+		decisions[c.Path] = p
+	}
+
+	result := &WriteResult{}
+	undo := &undoLog{}
+	if err := undo.mkdirAll(targetDir); err != nil {
+		return nil, err
+	}
+	for _, c := range changes {
+		if err := ctx.Err(); err != nil {
+			undo.rollback()
+			return nil, err
+		}
+		if err := writeChange(undo, targetDir, c, decisions[c.Path], result); err != nil {
+			undo.rollback()
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// writeChange writes a single planned change, applying decision to a
+// conflicting file.
+func writeChange(undo *undoLog, targetDir string, c FileChange, decision ConflictPolicy, result *WriteResult) error {
+	path := filepath.Join(targetDir, c.Path)
+	old := c.Old
+	switch c.Kind {
+	case ChangeUnchanged:
+		return nil
+	case ChangeModify:
+		switch decision {
+		case ConflictSkip:
+			result.Skipped = append(result.Skipped, c.Path)
+			return nil
+		case ConflictBackup:
+			backup, err := freeBackupPath(path)
+			if err != nil {
+				return err
+			}
+			if err := undo.rename(path, backup); err != nil {
+				return err
+			}
+			old = nil
+			result.BackedUp = append(result.BackedUp, Backup{
+				Path: c.Path,
+				To:   c.Path + strings.TrimPrefix(backup, path),
+			})
+		}
+	}
+
+	if err := undo.mkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	return undo.writeFile(path, c.Content, old)
+}
+
+// freeBackupPath returns the first of path.orig, path.orig.1, ... that does
+// not exist, so earlier backups are never overwritten.
+func freeBackupPath(path string) (string, error) {
+	for i := 0; ; i++ {
+		candidate := path + BackupSuffix
+		if i > 0 {
+			candidate += "." + strconv.Itoa(i)
+		}
+		_, err := os.Lstat(candidate)
+		if errors.Is(err, fs.ErrNotExist) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
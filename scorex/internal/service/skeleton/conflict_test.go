@@ -0,0 +1,98 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package skeleton
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func writeBackup(t *testing.T, dir, content string) *WriteResult {
+	t.Helper()
+	changes, err := Plan(dir, []File{{Path: "BUILD", Content: []byte(content)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := WriteChanges(context.Background(), dir, changes, ConflictBackup, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestBackupKeepsEarlierBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "BUILD")
+	if err := os.WriteFile(path, []byte("v0"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"BUILD.orig", "BUILD.orig.1", "BUILD.orig.2"} {
+		result := writeBackup(t, dir, fmt.Sprintf("v%d", i+1))
+		if len(result.BackedUp) != 1 || result.BackedUp[0].To != want {
+			t.Fatalf("run %d: BackedUp = %+v, want backup to %s", i+1, result.BackedUp, want)
+		}
+	}
+
+	for name, want := range map[string]string{
+		"BUILD":        "v3",
+		"BUILD.orig":   "v0",
+		"BUILD.orig.1": "v1",
+		"BUILD.orig.2": "v2",
+	} {
+		if got := readFile(t, filepath.Join(dir, name)); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestBackupRollbackKeepsExistingBackup(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"BUILD": "current", "BUILD.orig": "earlier"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, err := Plan(dir, []File{{Path: "BUILD", Content: []byte("new")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// BUILD is a file, so creating BUILD/sub fails after BUILD was backed up.
+	changes = append(changes, FileChange{File: File{Path: filepath.Join("BUILD", "sub")}, Kind: ChangeCreate})
+	if _, err := WriteChanges(context.Background(), dir, changes, ConflictBackup, nil); err == nil {
+		t.Fatal("expected the write to fail")
+	}
+
+	if got := readFile(t, filepath.Join(dir, "BUILD")); got != "current" {
+		t.Errorf("BUILD = %q after rollback, want %q", got, "current")
+	}
+	if got := readFile(t, filepath.Join(dir, "BUILD.orig")); got != "earlier" {
+		t.Errorf("BUILD.orig = %q after rollback, want %q", got, "earlier")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "BUILD.orig.1")); !os.IsNotExist(err) {
+		t.Errorf("BUILD.orig.1 left behind after rollback")
+	}
+}
@@ -13,12 +13,16 @@
 package skeleton
 
 import (
+    "bytes"
     "io/fs"
     "os"
+    "path"
     "path/filepath"
     "strings"
     "text/template"
 
+    "scorex/internal/service/modulefile"
+    "scorex/internal/service/templates"
     templatesfs "scorex/internal/templates"
 )
 
@@ -26,55 +30,67 @@ type moduleTemplateData struct {
     ProjectName     string
     SelectedModules map[string]any
     BazelVersion    string
+    // ModuleBlocks holds the bazel_dep and override blocks of the selected
+    // modules, rendered like update and add-module patch them.
+    ModuleBlocks string
 }
 
-func renderTemplate(tmplPath, dstPath string, data any) error {
-    if err := os.MkdirAll(filepath.Dir(dstPath), 0o755); err != nil {
-        return err
-    }
+// File is a single rendered file of a project skeleton.
+type File struct {
+    Path    string // relative to the project directory
+    Content []byte
+}
 
-    t, err := template.ParseFS(templatesfs.FS, tmplPath)
+func renderTemplate(fsys fs.FS, tmplPath string, data any) ([]byte, error) {
+    src, err := fs.ReadFile(fsys, tmplPath)
     if err != nil {
-        return err
+        return nil, err
     }
-
-    f, err := os.Create(dstPath)
+    t, err := template.New(path.Base(tmplPath)).Parse(string(src))
     if err != nil {
-        return err
+        return nil, err
     }
-    defer f.Close()
-
-    return t.Execute(f, data)
-}
 
-func undotifyPath(rel string) string {
-    // Work in slash-form, rewrite each segment, then convert back.
-    parts := strings.Split(filepath.ToSlash(rel), "/")
-    for i, p := range parts {
-        if strings.HasPrefix(p, "point.") {
-            parts[i] = "." + strings.TrimPrefix(p, "point.")
-        }
+    var buf bytes.Buffer
+    if err := t.Execute(&buf, data); err != nil {
+        return nil, err
     }
-    return filepath.FromSlash(strings.Join(parts, "/"))
+    // Values such as ModuleBlocks use \n; keep the template's line endings.
+    if bytes.Contains(src, []byte("\r\n")) {
+        out := bytes.ReplaceAll(buf.Bytes(), []byte("\r\n"), []byte("\n"))
+        return bytes.ReplaceAll(out, []byte("\n"), []byte("\r\n")), nil
+    }
+    return buf.Bytes(), nil
 }
 
 // Generate creates a project skeleton based on the provided properties.
 func Generate(props Properties) error {
-    targetDir := props.TargetDir
-
-    if err := os.MkdirAll(targetDir, 0o755); err != nil {
+    files, err := Render(props)
+    if err != nil {
         return err
     }
+    return Write(props.TargetDir, files)
+}
+
+// Render renders all files of a project skeleton into memory without
+// touching the file system.
+func Render(props Properties) ([]File, error) {
+    var files []File
 
     data := moduleTemplateData{
         ProjectName:     props.ProjectName,
         SelectedModules: toAnyMap(props.SelectedModules),
         BazelVersion:    props.BazelVersion,
+        ModuleBlocks:    modulefile.Render(props.SelectedModules),
     }
 
+    var fsys fs.FS = templatesfs.FS
     templatePath := "module"
 
-    if props.IsApplication {
+    if props.Template != nil {
+        fsys = props.Template.FS
+        templatePath = "."
+    } else if props.IsApplication {
         if props.UseFeo {
             templatePath = filepath.Join("application", "feo_app")
         } else {
@@ -82,7 +98,7 @@ func Generate(props Properties) error {
         }
     }
 
-    err := fs.WalkDir(templatesfs.FS, templatePath, func(path string, d fs.DirEntry, err error) error {
+    err := fs.WalkDir(fsys, templatePath, func(path string, d fs.DirEntry, err error) error {
         if err != nil {
             return err
         }
@@ -99,28 +115,41 @@ func Generate(props Properties) error {
         }
 
         // Optional: only include .devcontainer when requested.
-        slashRel := filepath.ToSlash(rel)
-        if !props.IncludeDevcontainer && strings.HasPrefix(slashRel, "point.devcontainer/") {
+        if !props.IncludeDevcontainer && templates.IsDevcontainerFile(rel) {
             return nil
         }
 
-        outRel := strings.TrimSuffix(rel, ".tmpl")
-		outRel = undotifyPath(outRel)
+        outRel := templates.OutputPath(rel)
 
-        base := filepath.Base(outRel)
-        if strings.HasPrefix(base, "point.") {
-            dir := filepath.Dir(outRel)
-            base = "." + strings.TrimPrefix(base, "point.")
-            outRel = filepath.Join(dir, base)
+        content, err := renderTemplate(fsys, path, data)
+        if err != nil {
+            return err
         }
-
-        dstPath := filepath.Join(targetDir, outRel)
-        return renderTemplate(path, dstPath, data)
+        files = append(files, File{Path: outRel, Content: content})
+        return nil
     })
     if err != nil {
+        return nil, err
+    }
+
+    return files, nil
+}
+
+// Write writes rendered files below targetDir, creating directories as needed.
+func Write(targetDir string, files []File) error {
+    if err := os.MkdirAll(targetDir, 0o755); err != nil {
         return err
     }
 
+    for _, f := range files {
+        dstPath := filepath.Join(targetDir, f.Path)
+        if err := os.MkdirAll(filepath.Dir(dstPath), 0o755); err != nil {
+            return err
+        }
+        if err := os.WriteFile(dstPath, f.Content, 0o644); err != nil {
+            return err
+        }
+    }
     return nil
 }
 
@@ -0,0 +1,109 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package skeleton
+
+import (
+    "strings"
+    "testing"
+
+    "scorex/internal/model"
+    "scorex/internal/service/modulefile"
+)
+
+// TestRenderPinModes renders the MODULE.bazel of every built-in layout with
+// one module per pin mode.
+func TestRenderPinModes(t *testing.T) {
+    modules := map[string]model.ModuleInfo{
+        "score_git": {Version: "1.0.0", Hash: "abc123", Repo: "https://github.com/eclipse-score/git.git"},
+        "score_registry": {Version: "1.1.0", Pin: &model.Pin{Mode: "registry"}},
+        "score_archive": {Version: "1.2.0", Pin: &model.Pin{
+            Mode:        "archive",
+            URL:         "https://example.com/archive.tar.gz",
+            Integrity:   "sha256-xyz",
+            StripPrefix: "archive-1.2.0",
+        }},
+        "score_local": {Version: "1.3.0", Pin: &model.Pin{Mode: "local", Path: "../local"}},
+    }
+    want := []string{
+        `git_override(
+    module_name = "score_git",
+    remote = "https://github.com/eclipse-score/git.git",
+    commit = "abc123",`,
+        `archive_override(
+    module_name = "score_archive",
+    urls = ["https://example.com/archive.tar.gz"],`,
+        `local_path_override(
+    module_name = "score_local",
+    path = "../local",`,
+    }
+
+    layouts := map[string]Properties{
+        "module":   {},
+        "daal_app": {IsApplication: true},
+        "feo_app":  {IsApplication: true, UseFeo: true},
+    }
+    for name, props := range layouts {
+        props.ProjectName = "demo"
+        props.SelectedModules = modules
+        files, err := Render(props)
+        if err != nil {
+            t.Errorf("%s: %v", name, err)
+            continue
+        }
+
+        var moduleFile string
+        for _, f := range files {
+            if f.Path == "MODULE.bazel" {
+                moduleFile = strings.ReplaceAll(string(f.Content), "\r\n", "\n")
+            }
+        }
+        for _, w := range want {
+            if !strings.Contains(moduleFile, w) {
+                t.Errorf("%s: MODULE.bazel lacks\n%s", name, w)
+            }
+        }
+        if strings.Contains(moduleFile, `module_name = "score_registry"`) {
+            t.Errorf("%s: registry-pinned module has an override", name)
+        }
+        if strings.Contains(moduleFile, "\n\n\n") {
+            t.Errorf("%s: MODULE.bazel has stray blank lines:\n%s", name, moduleFile)
+        }
+    }
+}
+
+// TestRenderMatchesPatch makes sure update lays out the module blocks of a
+// generated MODULE.bazel exactly like init did.
+func TestRenderMatchesPatch(t *testing.T) {
+    modules := map[string]model.ModuleInfo{
+        "score_baselibs": {Version: "1.0.0", Hash: "abc123", Repo: "https://github.com/eclipse-score/baselibs.git"},
+        "score_feo": {Version: "1.1.0", Pin: &model.Pin{Mode: "registry"}},
+    }
+    files, err := Render(Properties{ProjectName: "demo", SelectedModules: modules, IsApplication: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, f := range files {
+        if f.Path != modulefile.FileName {
+            continue
+        }
+        patched, err := modulefile.Patch(string(f.Content), []string{"score_baselibs", "score_feo"}, modules)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if patched != string(f.Content) {
+            t.Errorf("Patch changed the generated MODULE.bazel:\n%s\nwant:\n%s", patched, f.Content)
+        }
+        return
+    }
+    t.Fatal("no MODULE.bazel rendered")
+}
@@ -0,0 +1,56 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package skeleton
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ChangeKind describes what writing a rendered file would do on disk.
+type ChangeKind string
+
+const (
+	ChangeCreate    ChangeKind = "create"
+	ChangeModify    ChangeKind = "change"
+	ChangeUnchanged ChangeKind = "unchanged"
+)
+
+// FileChange pairs a rendered file with the content currently on disk.
+type FileChange struct {
+	File
+	Kind ChangeKind
+	Old  []byte // content on disk, nil for ChangeCreate
+}
+
+// Plan compares rendered files with what exists below targetDir.
+func Plan(targetDir string, files []File) ([]FileChange, error) {
+	changes := make([]FileChange, 0, len(files))
+	for _, f := range files {
+		old, err := os.ReadFile(filepath.Join(targetDir, f.Path))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, FileChange{File: f, Kind: ChangeCreate})
+		case err != nil:
+			return nil, err
+		case bytes.Equal(old, f.Content):
+			changes = append(changes, FileChange{File: f, Kind: ChangeUnchanged, Old: old})
+		default:
+			changes = append(changes, FileChange{File: f, Kind: ChangeModify, Old: old})
+		}
+	}
+	return changes, nil
+}
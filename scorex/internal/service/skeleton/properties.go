@@ -1,6 +1,9 @@
 package skeleton
 
-import "scorex/internal/model"
+import (
+    "scorex/internal/model"
+    "scorex/internal/service/templates"
+)
 
 // Properties holds all data required to render a project skeleton.
 type Properties struct {
@@ -11,4 +14,5 @@ type Properties struct {
     IsApplication   bool
     UseFeo          bool
 	IncludeDevcontainer bool
+    Template        *templates.Template // overrides IsApplication/UseFeo when set
 }
@@ -0,0 +1,77 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package skeleton
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// undoLog records the changes WriteChanges makes on disk, so that an
+// interrupted or failed write can be reverted.
+type undoLog struct {
+	steps []func()
+}
+
+// mkdirAll works like os.MkdirAll and records the directories it creates.
+func (u *undoLog) mkdirAll(dir string) error {
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); !errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := os.Mkdir(missing[i], 0o755); err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		d := missing[i]
+		u.steps = append(u.steps, func() { os.Remove(d) })
+	}
+	return nil
+}
+
+// rename renames a file and records how to move it back.
+func (u *undoLog) rename(from, to string) error {
+	if err := os.Rename(from, to); err != nil {
+		return err
+	}
+	u.steps = append(u.steps, func() { os.Rename(to, from) })
+	return nil
+}
+
+// writeFile writes content to path, recording old as the content to restore,
+// or that path is to be removed if old is nil.
+func (u *undoLog) writeFile(path string, content, old []byte) error {
+	if old == nil {
+		u.steps = append(u.steps, func() { os.Remove(path) })
+	} else {
+		u.steps = append(u.steps, func() { os.WriteFile(path, old, 0o644) })
+	}
+	return os.WriteFile(path, content, 0o644)
+}
+
+// rollback reverts the recorded changes, newest first. It is best effort:
+// what cannot be reverted is left as it is.
+func (u *undoLog) rollback() {
+	for i := len(u.steps) - 1; i >= 0; i-- {
+		u.steps[i]()
+	}
+	u.steps = nil
+}
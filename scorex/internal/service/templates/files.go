@@ -0,0 +1,158 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package templates
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateSuffix marks files that are rendered into a project.
+const TemplateSuffix = ".tmpl"
+
+const dotPrefix = "point."
+
+// OutputPath maps the path of a template file to the path of the rendered
+// file: the .tmpl suffix is dropped and "point." prefixes of path segments
+// become ".", e.g. point.devcontainer/devcontainer.json.tmpl turns into
+// .devcontainer/devcontainer.json.
+func OutputPath(rel string) string {
+	parts := strings.Split(filepath.ToSlash(strings.TrimSuffix(rel, TemplateSuffix)), "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, dotPrefix) {
+			parts[i] = "." + strings.TrimPrefix(p, dotPrefix)
+		}
+	}
+	return filepath.FromSlash(strings.Join(parts, "/"))
+}
+
+// IsDevcontainerFile reports whether a template file belongs to the optional
+// .devcontainer folder.
+func IsDevcontainerFile(rel string) bool {
+	return strings.HasPrefix(filepath.ToSlash(rel), dotPrefix+"devcontainer/")
+}
+
+// TemplateFile is a file of a template and the path it is rendered to.
+type TemplateFile struct {
+	Path     string // path of the .tmpl file within the template
+	Output   string // path of the rendered file within the project
+	Optional bool   // only rendered on request, e.g. .devcontainer
+}
+
+// Files lists all files a template renders, sorted by output path.
+func (t *Template) Files() ([]TemplateFile, error) {
+	var out []TemplateFile
+	err := fs.WalkDir(t.FS, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, TemplateSuffix) {
+			return nil
+		}
+		out = append(out, TemplateFile{
+			Path:     p,
+			Output:   filepath.ToSlash(OutputPath(p)),
+			Optional: IsDevcontainerFile(p),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Output < out[j].Output })
+	return out, nil
+}
+
+// Variables returns the template data fields referenced by a template's
+// files, e.g. ".ProjectName" or "$m.Version" inside a range, sorted.
+func (t *Template) Variables() ([]string, error) {
+	files, err := t.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	for _, f := range files {
+		data, err := fs.ReadFile(t.FS, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		// Parse like the generator does, so builtins such as eq are known.
+		tmpl, err := template.New(path.Base(f.Path)).Parse(string(data))
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil {
+				collectFields(t.Tree.Root, seen)
+			}
+		}
+	}
+
+	out := make([]string, 0, len(seen))
+	for v := range seen {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func collectFields(node parse.Node, seen map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collectFields(c, seen)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			collectFields(c, seen)
+		}
+	case *parse.CommandNode:
+		for _, a := range n.Args {
+			collectFields(a, seen)
+		}
+	case *parse.FieldNode:
+		seen["."+strings.Join(n.Ident, ".")] = struct{}{}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 {
+			seen[strings.Join(n.Ident, ".")] = struct{}{}
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, seen)
+	case *parse.IfNode:
+		collectFields(&n.BranchNode, seen)
+	case *parse.RangeNode:
+		collectFields(&n.BranchNode, seen)
+	case *parse.WithNode:
+		collectFields(&n.BranchNode, seen)
+	case *parse.BranchNode:
+		collectFields(n.Pipe, seen)
+		collectFields(n.List, seen)
+		collectFields(n.ElseList, seen)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, seen)
+	}
+}
@@ -0,0 +1,59 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package templates
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	templatesfs "scorex/internal/templates"
+)
+
+func TestVariablesEmbedded(t *testing.T) {
+	set := &Set{byID: make(map[string]*Template)}
+	if err := set.add(templatesfs.FS, SourceEmbedded); err != nil {
+		t.Fatal(err)
+	}
+	if len(set.All()) == 0 {
+		t.Fatal("no embedded templates found")
+	}
+
+	for _, tmpl := range set.All() {
+		vars, err := tmpl.Variables()
+		if err != nil {
+			t.Errorf("%s: %v", tmpl.ID, err)
+			continue
+		}
+		if !slices.Contains(vars, ".ProjectName") {
+			t.Errorf("%s: variables %v lack .ProjectName", tmpl.ID, vars)
+		}
+	}
+}
+
+func TestVariablesBuiltinFuncs(t *testing.T) {
+	tmpl := &Template{FS: fstest.MapFS{
+		"MODULE.bazel.tmpl": {Data: []byte(
+			`{{ range $n, $m := .Modules }}{{ if eq $m.PinMode "git" }}{{ printf "%s" $m.Repo }}{{ end }}{{ end }}`,
+		)},
+	}}
+
+	vars, err := tmpl.Variables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"$m.PinMode", "$m.Repo", ".Modules"}
+	if !slices.Equal(vars, want) {
+		t.Errorf("Variables() = %v, want %v", vars, want)
+	}
+}
@@ -0,0 +1,199 @@
+/********************************************************************************
+* Copyright (c) 2026 Contributors to the Eclipse Foundation
+*
+* See the NOTICE file(s) distributed with this work for additional
+* information regarding copyright ownership.
+*
+* This program and the accompanying materials are made available under the
+* terms of the Apache License Version 2.0 which is available at
+* https://www.apache.org/licenses/LICENSE-2.0
+*
+* SPDX-License-Identifier: Apache-2.0
+********************************************************************************/
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"scorex/internal/service/module"
+	templatesfs "scorex/internal/templates"
+)
+
+// ManifestFileName is the file that marks a directory as a project template.
+const ManifestFileName = "template.json"
+
+// SourceEmbedded is the Source of templates built into scorex.
+const SourceEmbedded = "embedded"
+
+// Manifest describes a project template.
+type Manifest struct {
+	ID              string   `json:"id"`
+	Description     string   `json:"description,omitempty"`
+	ProjectType     string   `json:"projectType"`       // "Application" or "Module"
+	AppType         string   `json:"appType,omitempty"` // e.g. "daal" or "feo"
+	RequiredModules []string `json:"requiredModules,omitempty"`
+	DefaultModules  []string `json:"defaultModules,omitempty"`
+}
+
+// Template is a project template together with the files it renders.
+type Template struct {
+	Manifest
+	FS     fs.FS  // rooted at the template directory
+	Source string // SourceEmbedded or the directory the template was found in
+}
+
+// MissingModules returns the modules the template requires that are not in
+// selected, which must hold normalized module names.
+func (t *Template) MissingModules(selected []string) []string {
+	var missing []string
+	for _, req := range t.RequiredModules {
+		if !slices.Contains(selected, req) {
+			missing = append(missing, req)
+		}
+	}
+	return missing
+}
+
+// Set holds all available templates by ID. Templates from later sources
+// replace embedded ones with the same ID.
+type Set struct {
+	byID map[string]*Template
+}
+
+// UserDir returns the per-user template search path,
+// e.g. ~/.config/scorex/templates on Linux.
+func UserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scorex", "templates"), nil
+}
+
+// Load collects the embedded templates, then those in the user template
+// directory (if it exists) and finally those in extraDir (if set). Later
+// sources take precedence.
+func Load(extraDir string) (*Set, error) {
+	s := &Set{byID: make(map[string]*Template)}
+
+	if err := s.add(templatesfs.FS, SourceEmbedded); err != nil {
+		return nil, fmt.Errorf("loading embedded templates: %w", err)
+	}
+
+	if userDir, err := UserDir(); err == nil {
+		if _, err := os.Stat(userDir); err == nil {
+			if err := s.add(os.DirFS(userDir), userDir); err != nil {
+				return nil, fmt.Errorf("loading templates from %s: %w", userDir, err)
+			}
+		}
+	}
+
+	if extraDir != "" {
+		if _, err := os.Stat(extraDir); err != nil {
+			return nil, fmt.Errorf("template directory: %w", err)
+		}
+		if err := s.add(os.DirFS(extraDir), extraDir); err != nil {
+			return nil, fmt.Errorf("loading templates from %s: %w", extraDir, err)
+		}
+	}
+
+	return s, nil
+}
+
+// Lookup returns the template with the given ID.
+func (s *Set) Lookup(id string) (*Template, bool) {
+	t, ok := s.byID[id]
+	return t, ok
+}
+
+// All returns all templates sorted by ID.
+func (s *Set) All() []*Template {
+	out := make([]*Template, 0, len(s.byID))
+	for _, t := range s.byID {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// IDs returns the IDs of all templates, sorted.
+func (s *Set) IDs() []string {
+	ids := make([]string, 0, len(s.byID))
+	for id := range s.byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// add registers every directory below root that contains a template.json.
+func (s *Set) add(root fs.FS, source string) error {
+	return fs.WalkDir(root, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		data, err := fs.ReadFile(root, path.Join(p, ManifestFileName))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		m, err := parseManifest(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path.Join(p, ManifestFileName), err)
+		}
+		sub, err := fs.Sub(root, p)
+		if err != nil {
+			return err
+		}
+
+		src := source
+		if source != SourceEmbedded {
+			src = filepath.Join(source, filepath.FromSlash(p))
+		}
+		s.byID[m.ID] = &Template{Manifest: m, FS: sub, Source: src}
+
+		// Templates do not nest.
+		return fs.SkipDir
+	})
+}
+
+func parseManifest(data []byte) (Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, err
+	}
+
+	m.ID = strings.TrimSpace(m.ID)
+	m.ProjectType = strings.TrimSpace(m.ProjectType)
+	m.AppType = strings.TrimSpace(m.AppType)
+
+	if m.ID == "" {
+		return Manifest{}, fmt.Errorf("template missing id")
+	}
+	if m.ProjectType != "Application" && m.ProjectType != "Module" {
+		return Manifest{}, fmt.Errorf("template %q: invalid projectType %q (use Application or Module)", m.ID, m.ProjectType)
+	}
+	for i, n := range m.RequiredModules {
+		m.RequiredModules[i] = module.NormalizeName(strings.TrimSpace(n))
+	}
+	for i, n := range m.DefaultModules {
+		m.DefaultModules[i] = module.NormalizeName(strings.TrimSpace(n))
+	}
+	return m, nil
+}